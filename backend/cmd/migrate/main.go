@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"naradai-backend/internal/config"
+	"naradai-backend/internal/migrate"
+)
+
+const usage = `Usage: migrate <command> [flags]
+
+Commands:
+  up [-to VERSION]   apply pending migrations, up to VERSION if given
+  down [-steps N]    roll back the N most recently applied migrations (default 1)
+  status             list migrations and when they were applied
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		exit(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDBURI))
+	if err != nil {
+		exit(fmt.Errorf("connect to MongoDB: %w", err))
+	}
+	defer client.Disconnect(context.Background())
+	if err := client.Ping(ctx, nil); err != nil {
+		exit(fmt.Errorf("ping MongoDB: %w", err))
+	}
+
+	migrator := migrate.New(client.Database(cfg.MongoDBDatabase), migrate.Migrations)
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "up":
+		flags := flag.NewFlagSet("up", flag.ExitOnError)
+		target := flags.Int("to", 0, "apply migrations up to and including this version")
+		flags.Parse(args)
+
+		applied, err := migrator.Up(context.Background(), *target)
+		report("Applied", applied)
+		if err != nil {
+			exit(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("No pending migrations")
+		}
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "number of migrations to roll back")
+		flags.Parse(args)
+
+		rolledBack, err := migrator.Down(context.Background(), *steps)
+		report("Rolled back", rolledBack)
+		if err != nil {
+			exit(err)
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No applied migrations")
+		}
+	case "status":
+		states, err := migrator.Status(context.Background())
+		if err != nil {
+			exit(err)
+		}
+		for _, state := range states {
+			applied := "pending"
+			if state.AppliedAt != nil {
+				applied = state.AppliedAt.Local().Format(time.RFC3339)
+			}
+			fmt.Printf("%4d  %-25s  %s\n", state.Version, applied, state.Description)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+func report(verb string, migrations []migrate.Migration) {
+	for _, migration := range migrations {
+		fmt.Printf("%s %d: %s\n", verb, migration.Version, migration.Description)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "migrate:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"naradai-backend/internal/archive"
+	"naradai-backend/internal/config"
+	"naradai-backend/internal/migrate"
+)
+
+const usage = `Usage: naradai <command> [flags]
+
+Commands:
+  dump [-workspace DB] [-collections a,b] [-o FILE]
+      write the collections of a workspace to an archive
+  restore [-workspace DB] [-collections a,b] [-remap-ids] [-replace] FILE
+      restore an archive into a workspace
+  verify FILE
+      check an archive against its manifest
+
+A workspace is a database; it defaults to MONGODB_DATABASE.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "dump":
+		dump(args)
+	case "restore":
+		restore(args)
+	case "verify":
+		verify(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+func dump(args []string) {
+	flags := flag.NewFlagSet("dump", flag.ExitOnError)
+	workspace := flags.String("workspace", "", "database to dump (default MONGODB_DATABASE)")
+	collections := flags.String("collections", "", "comma-separated collections to dump (default all)")
+	output := flags.String("o", "", "archive file to write (default naradai-<workspace>-<time>.tar)")
+	flags.Parse(args)
+
+	db, disconnect := connect(*workspace)
+	defer disconnect()
+
+	version, err := migrate.New(db, migrate.Migrations).Version(context.Background())
+	if err != nil {
+		exit(err)
+	}
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("naradai-%s-%s.tar", db.Name(), time.Now().UTC().Format("20060102T150405Z"))
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		exit(err)
+	}
+
+	manifest, err := archive.Dump(context.Background(), db, file, archive.DumpOptions{
+		Collections:   list(*collections),
+		SchemaVersion: version,
+	})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		exit(err)
+	}
+	for _, c := range manifest.Collections {
+		fmt.Printf("Dumped %d %s\n", c.Documents, c.Name)
+	}
+	fmt.Printf("Wrote %s\n", path)
+}
+
+func restore(args []string) {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	workspace := flags.String("workspace", "", "database to restore into (default MONGODB_DATABASE)")
+	collections := flags.String("collections", "", "comma-separated collections to restore (default all)")
+	remapIDs := flags.Bool("remap-ids", false, "give restored documents new IDs, keeping the references between them")
+	replace := flags.Bool("replace", false, "delete the documents of the restored collections first")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		exit(err)
+	}
+	defer file.Close()
+
+	db, disconnect := connect(*workspace)
+	defer disconnect()
+
+	version, err := migrate.New(db, migrate.Migrations).Version(context.Background())
+	if err != nil {
+		exit(err)
+	}
+	manifest, restored, err := archive.Restore(context.Background(), db, file, archive.RestoreOptions{
+		Collections:   list(*collections),
+		RemapIDs:      *remapIDs,
+		Replace:       *replace,
+		SchemaVersion: version,
+	})
+	for _, r := range restored {
+		fmt.Printf("Restored %d %s\n", r.Documents, r.Collection)
+	}
+	if err != nil {
+		exit(err)
+	}
+	fmt.Printf("Restored %s (dumped from %s at %s) into %s\n", flags.Arg(0), manifest.Database, manifest.CreatedAt.Format(time.RFC3339), db.Name())
+}
+
+func verify(args []string) {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		exit(err)
+	}
+	defer file.Close()
+
+	manifest, err := archive.Verify(file)
+	if err != nil {
+		exit(err)
+	}
+	fmt.Printf("Archive version %d of %s at schema version %d, dumped at %s\n", manifest.Version, manifest.Database, manifest.SchemaVersion, manifest.CreatedAt.Format(time.RFC3339))
+	for _, c := range manifest.Collections {
+		fmt.Printf("%8d  %s\n", c.Documents, c.Name)
+	}
+}
+
+// connect opens the workspace database, falling back to MONGODB_DATABASE.
+func connect(workspace string) (*mongo.Database, func()) {
+	godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		exit(err)
+	}
+	if workspace == "" {
+		workspace = cfg.MongoDBDatabase
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDBURI))
+	if err != nil {
+		exit(fmt.Errorf("connect to MongoDB: %w", err))
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		exit(fmt.Errorf("ping MongoDB: %w", err))
+	}
+	return client.Database(workspace), func() { client.Disconnect(context.Background()) }
+}
+
+func list(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "naradai:", err)
+	os.Exit(1)
+}
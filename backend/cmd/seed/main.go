@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"naradai-backend/internal/config"
+	"naradai-backend/internal/repository"
+	"naradai-backend/internal/resource"
+	"naradai-backend/internal/seed"
+	"naradai-backend/internal/service"
+)
+
+// files collects the repeatable -fixtures flag.
+type files []string
+
+func (f *files) String() string { return strings.Join(*f, ",") }
+
+func (f *files) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	var fixtureFiles files
+	flag.Var(&fixtureFiles, "fixtures", "YAML or JSON fixture file to load (repeatable)")
+	generate := flag.Bool("generate", false, "load synthetic data")
+	seedValue := flag.Int64("seed", 1, "random seed of the synthetic data")
+	size := flag.Int("size", 6, "number of synthetic items per collection")
+	reset := flag.Bool("reset", false, "delete the existing dashboard content first")
+	force := flag.Bool("force", false, "allow -reset when GIN_MODE is release")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: seed [-reset] [-fixtures FILE]... [-generate [-seed N] [-size N]]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if len(fixtureFiles) == 0 && !*generate && !*reset {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *size < 1 {
+		exit(fmt.Errorf("-size must be at least 1"))
+	}
+
+	godotenv.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		exit(err)
+	}
+	if *reset && cfg.GinMode == "release" && !*force {
+		exit(fmt.Errorf("refusing to reset %s while GIN_MODE is release; pass -force to do it anyway", cfg.MongoDBDatabase))
+	}
+
+	// Read every fixture before touching the database
+	fixtures := &seed.Fixtures{}
+	for _, path := range fixtureFiles {
+		loaded, err := seed.ReadFile(path)
+		if err != nil {
+			exit(err)
+		}
+		fixtures.Append(loaded)
+	}
+	if *generate {
+		fixtures.Append(seed.Generate(*seedValue, *size, time.Now()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDBURI))
+	if err != nil {
+		exit(fmt.Errorf("connect to MongoDB: %w", err))
+	}
+	defer client.Disconnect(context.Background())
+	if err := client.Ping(ctx, nil); err != nil {
+		exit(fmt.Errorf("ping MongoDB: %w", err))
+	}
+	db := client.Database(cfg.MongoDBDatabase)
+
+	items := service.NewItems(
+		resource.NewRepository(db, resource.ConversationClusters),
+		resource.NewRepository(db, resource.DiscussionTopics),
+		resource.NewRepository(db, resource.Risks),
+		resource.NewRepository(db, resource.Opportunities),
+		resource.NewRepository(db, resource.PriorityActions),
+	)
+	relationSvc := service.NewRelationService(repository.NewRelationRepository(db), items)
+	evidenceSvc := service.NewEvidenceService(repository.NewEvidenceRepository(db), items)
+	svc := seed.Services{
+		PriorityActions:      service.NewPriorityActionService(resource.NewRepository(db, resource.PriorityActions), relationSvc, evidenceSvc),
+		DashboardStats:       service.NewDashboardStatService(resource.NewRepository(db, resource.DashboardStats), repository.NewStatPointRepository(db), repository.NewStatMetricRepository(db)),
+		SentimentTrends:      service.NewSentimentTrendService(resource.NewRepository(db, resource.SentimentTrends)),
+		DiscussionTopics:     service.NewDiscussionTopicService(resource.NewRepository(db, resource.DiscussionTopics), relationSvc, evidenceSvc),
+		CompetitiveAnalyses:  service.NewCompetitiveAnalysisService(resource.NewRepository(db, resource.CompetitiveAnalyses)),
+		ConversationClusters: service.NewConversationClusterService(resource.NewRepository(db, resource.ConversationClusters), relationSvc, evidenceSvc),
+		Risks:                service.NewRiskService(resource.NewRepository(db, resource.Risks), relationSvc, evidenceSvc),
+		Opportunities:        service.NewOpportunityService(resource.NewRepository(db, resource.Opportunities), relationSvc, evidenceSvc),
+	}
+
+	// Reject invalid fixtures before anything is deleted
+	if err := seed.Validate(svc, fixtures); err != nil {
+		exit(err)
+	}
+	if *reset {
+		deleted, err := seed.Reset(context.Background(), db)
+		report("Deleted", deleted)
+		if err != nil {
+			exit(err)
+		}
+	}
+
+	created, err := seed.Load(context.Background(), svc, fixtures)
+	report("Created", created)
+	if err != nil {
+		exit(err)
+	}
+}
+
+func report(verb string, counts []seed.Count) {
+	for _, count := range counts {
+		if count.Documents > 0 {
+			fmt.Printf("%s %d %s\n", verb, count.Documents, count.Collection)
+		}
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, "seed:", err)
+	os.Exit(1)
+}
@@ -2,7 +2,8 @@ package main
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,162 +15,267 @@ import (
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
 
 	"naradai-backend/internal/config"
 	"naradai-backend/internal/handler"
+	"naradai-backend/internal/health"
+	"naradai-backend/internal/idempotency"
+	"naradai-backend/internal/logging"
+	"naradai-backend/internal/mailer"
+	"naradai-backend/internal/metrics"
+	"naradai-backend/internal/migrate"
+	"naradai-backend/internal/report"
 	"naradai-backend/internal/repository"
+	"naradai-backend/internal/resource"
+	"naradai-backend/internal/scheduler"
 	"naradai-backend/internal/service"
+	"naradai-backend/internal/telemetry"
 )
 
 func main() {
+	// JSON logs at info level until the configured level is known
+	slog.SetDefault(logging.New(os.Stdout, slog.LevelInfo))
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using default values")
+		slog.Info("No .env file found, using default values")
 	}
 
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	logger := logging.New(os.Stdout, cfg.LogLevel)
+	slog.SetDefault(logger)
+
+	// Tracing
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.TracesExporter, cfg.OTLPEndpoint, cfg.ServiceName)
+	if err != nil {
+		fatal("Failed to set up tracing", err)
+	}
+
+	// Prometheus metrics, including the MongoDB command monitor
+	appMetrics := metrics.New()
 
 	// Connect to MongoDB
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDBURI))
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoDBURI).SetMonitor(telemetry.CombineMonitors(
+		appMetrics.CommandMonitor(),
+		otelmongo.NewMonitor(),
+	)))
 	if err != nil {
-		log.Fatal("Failed to connect to MongoDB:", err)
+		fatal("Failed to connect to MongoDB", err)
 	}
 	defer client.Disconnect(ctx)
 
 	// Ping database
 	if err := client.Ping(ctx, nil); err != nil {
-		log.Fatal("Failed to ping MongoDB:", err)
+		fatal("Failed to ping MongoDB", err)
 	}
 
-	log.Println("Connected to MongoDB successfully")
+	slog.Info("Connected to MongoDB successfully", "database", cfg.MongoDBDatabase)
 
 	db := client.Database(cfg.MongoDBDatabase)
 
+	// Apply pending migrations when asked to; otherwise run cmd/migrate
+	if cfg.MigrateOnStart {
+		applied, err := migrate.New(db, migrate.Migrations).Up(context.Background(), 0)
+		if err != nil {
+			fatal("Failed to migrate the database", err)
+		}
+		for _, migration := range applied {
+			slog.Info("Applied migration", "version", migration.Version, "description", migration.Description)
+		}
+	}
+
+	// Idempotency keys for POST requests
+	idempotencyStore := idempotency.NewMongoStore(db, cfg.IdempotencyTTL)
+	if err := idempotencyStore.EnsureIndexes(ctx); err != nil {
+		fatal("Failed to create idempotency indexes", err)
+	}
+
+	// Initialize Relation and Evidence layers. The resource services cascade
+	// deletes to both.
+	relationRepo := repository.NewRelationRepository(db)
+	if err := relationRepo.EnsureIndexes(ctx); err != nil {
+		fatal("Failed to create relation indexes", err)
+	}
+	items := service.NewItems(
+		resource.NewRepository(db, resource.ConversationClusters),
+		resource.NewRepository(db, resource.DiscussionTopics),
+		resource.NewRepository(db, resource.Risks),
+		resource.NewRepository(db, resource.Opportunities),
+		resource.NewRepository(db, resource.PriorityActions),
+	)
+	relationSvc := service.NewRelationService(relationRepo, items)
+	relationHandler := handler.NewRelationHandler(relationSvc)
+	evidenceRepo := repository.NewEvidenceRepository(db)
+	if err := evidenceRepo.EnsureIndexes(ctx); err != nil {
+		fatal("Failed to create evidence indexes", err)
+	}
+	evidenceSvc := service.NewEvidenceService(evidenceRepo, items)
+	evidenceHandler := handler.NewEvidenceHandler(evidenceSvc)
+
 	// Initialize Priority Action layers
-	repo := repository.NewPriorityActionRepository(db)
-	svc := service.NewPriorityActionService(repo)
+	repo := resource.NewRepository(db, resource.PriorityActions)
+	svc := service.NewPriorityActionService(repo, relationSvc, evidenceSvc)
 	h := handler.NewPriorityActionHandler(svc)
 
 	// Initialize Dashboard Stat layers
-	statRepo := repository.NewDashboardStatRepository(db)
-	statSvc := service.NewDashboardStatService(statRepo)
+	statRepo := resource.NewRepository(db, resource.DashboardStats)
+	statPointRepo := repository.NewStatPointRepository(db)
+	if err := statPointRepo.EnsureIndexes(ctx); err != nil {
+		fatal("Failed to create dashboard stat point indexes", err)
+	}
+	statMetricRepo := repository.NewStatMetricRepository(db)
+	statSvc := service.NewDashboardStatService(statRepo, statPointRepo, statMetricRepo)
+	appMetrics.Register(metrics.NewBusinessCollector(statMetricRepo))
 	statHandler := handler.NewDashboardStatHandler(statSvc)
 
 	// Initialize Risk layers
-	riskRepo := repository.NewRiskRepository(db)
-	riskSvc := service.NewRiskService(riskRepo)
+	riskRepo := resource.NewRepository(db, resource.Risks)
+	riskSvc := service.NewRiskService(riskRepo, relationSvc, evidenceSvc)
 	riskHandler := handler.NewRiskHandler(riskSvc)
 
 	// Initialize Opportunity layers
-	oppRepo := repository.NewOpportunityRepository(db)
-	oppSvc := service.NewOpportunityService(oppRepo)
+	oppRepo := resource.NewRepository(db, resource.Opportunities)
+	oppSvc := service.NewOpportunityService(oppRepo, relationSvc, evidenceSvc)
 	oppHandler := handler.NewOpportunityHandler(oppSvc)
 
 	// Initialize Sentiment Trend layers
-	sentimentTrendRepo := repository.NewSentimentTrendRepository(db)
+	sentimentTrendRepo := resource.NewRepository(db, resource.SentimentTrends)
 	sentimentTrendSvc := service.NewSentimentTrendService(sentimentTrendRepo)
 	sentimentTrendHandler := handler.NewSentimentTrendHandler(sentimentTrendSvc)
 
 	// Initialize Discussion Topic layers
-	discussionTopicRepo := repository.NewDiscussionTopicRepository(db)
-	discussionTopicSvc := service.NewDiscussionTopicService(discussionTopicRepo)
+	discussionTopicRepo := resource.NewRepository(db, resource.DiscussionTopics)
+	discussionTopicSvc := service.NewDiscussionTopicService(discussionTopicRepo, relationSvc, evidenceSvc)
 	discussionTopicHandler := handler.NewDiscussionTopicHandler(discussionTopicSvc)
 
 	// Initialize Competitive Analysis layers
-	competitiveAnalysisRepo := repository.NewCompetitiveAnalysisRepository(db)
+	competitiveAnalysisRepo := resource.NewRepository(db, resource.CompetitiveAnalyses)
 	competitiveAnalysisSvc := service.NewCompetitiveAnalysisService(competitiveAnalysisRepo)
 	competitiveAnalysisHandler := handler.NewCompetitiveAnalysisHandler(competitiveAnalysisSvc)
 
 	// Initialize Conversation Cluster layers
-	conversationClusterRepo := repository.NewConversationClusterRepository(db)
-	conversationClusterSvc := service.NewConversationClusterService(conversationClusterRepo)
+	conversationClusterRepo := resource.NewRepository(db, resource.ConversationClusters)
+	conversationClusterSvc := service.NewConversationClusterService(conversationClusterRepo, relationSvc, evidenceSvc)
 	conversationClusterHandler := handler.NewConversationClusterHandler(conversationClusterSvc)
 
+	// Initialize Anomaly layers
+	anomalySvc := service.NewAnomalyService(sentimentTrendSvc, statSvc)
+	anomalyHandler := handler.NewAnomalyHandler(anomalySvc)
+	adminHandler := handler.NewAdminHandler(cfg)
+
+	// Initialize Dashboard layers
+	dashboardSvc := service.NewDashboardService(statSvc, svc, riskSvc, oppSvc, sentimentTrendSvc, discussionTopicSvc, competitiveAnalysisSvc, conversationClusterSvc)
+	dashboardHandler := handler.NewDashboardHandler(dashboardSvc)
+	brand := report.Brand{
+		Name:     cfg.ReportBrandName,
+		Color:    cfg.ReportBrandColor,
+		LogoPath: cfg.ReportLogoPath,
+	}
+	reportHandler := handler.NewReportHandler(dashboardSvc, brand)
+
+	// Initialize Report Schedule layers and the background scheduler
+	reportScheduleRepo := repository.NewReportScheduleRepository(db)
+	reportRunRepo := repository.NewReportRunRepository(db)
+	reportScheduleSvc := service.NewReportScheduleService(reportScheduleRepo, reportRunRepo)
+	hostname, _ := os.Hostname()
+	backgroundScheduler := scheduler.New(
+		db,
+		reportScheduleSvc,
+		report.NewGenerator(dashboardSvc, brand),
+		mailer.New(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom),
+		scheduler.NewLock(db, "report-scheduler", fmt.Sprintf("%s-%d", hostname, os.Getpid()), 3*cfg.SchedulerInterval),
+		cfg.SchedulerInterval,
+	)
+	reportScheduleHandler := handler.NewReportScheduleHandler(reportScheduleSvc, backgroundScheduler)
+
+	// Initialize Snapshot layers
+	snapshotRepo := repository.NewSnapshotRepository(db)
+	if err := snapshotRepo.EnsureIndexes(ctx); err != nil {
+		fatal("Failed to create snapshot indexes", err)
+	}
+	snapshotSvc := service.NewSnapshotService(snapshotRepo, dashboardSvc)
+	snapshotHandler := handler.NewSnapshotHandler(snapshotSvc)
+	if cfg.SnapshotCron != "" {
+		if err := backgroundScheduler.AddJob("dashboard-snapshot", cfg.SnapshotCron, snapshotSvc.TakeScheduled); err != nil {
+			fatal("Invalid SNAPSHOT_CRON", err)
+		}
+	}
+	if cfg.StatRefreshCron != "" {
+		if err := backgroundScheduler.AddJob("dashboard-stat-refresh", cfg.StatRefreshCron, statSvc.RefreshBound); err != nil {
+			fatal("Invalid STAT_REFRESH_CRON", err)
+		}
+	}
+
 	// Setup Gin router
 	if cfg.GinMode == "release" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.Default()
+	router := gin.New()
+	router.Use(logging.Middleware(logger), logging.Recovery(logger), otelgin.Middleware(cfg.ServiceName), appMetrics.Middleware())
 
 	// CORS middleware
 	router.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"http://localhost:5173", "http://localhost:3000", "http://127.0.0.1:5173", "https://staging.teoremaintelligence.com"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowMethods:     cfg.CORSAllowedMethods,
+		AllowHeaders:     cfg.CORSAllowedHeaders,
+		ExposeHeaders:    []string{"Content-Length", "ETag", "Idempotent-Replayed", logging.HeaderRequestID},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
 
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
+	// Health checks
+	checker := health.New(cfg.ReadinessTimeout)
+	checker.Register("mongodb", func(ctx context.Context) error {
+		return client.Ping(ctx, readpref.Primary())
 	})
+	if cfg.SchedulerEnabled {
+		checker.Register("scheduler", backgroundScheduler.Check)
+	}
+	healthHandler := handler.NewHealthHandler(checker)
+	router.GET("/health", healthHandler.Live)
+	router.GET("/livez", healthHandler.Live)
+	router.GET("/readyz", healthHandler.Ready)
+	router.GET("/metrics", gin.WrapH(appMetrics.Handler()))
 
 	// API routes
 	api := router.Group("/api/v1")
-	{
-		// Priority Actions routes
-		api.GET("/priority-actions", h.GetAll)
-		api.GET("/priority-actions/:id", h.GetByID)
-		api.POST("/priority-actions", h.Create)
-		api.PUT("/priority-actions/:id", h.Update)
-		api.PUT("/priority-actions/:id/status", h.UpdateStatus)
-		api.DELETE("/priority-actions/:id", h.Delete)
-
-		// Dashboard Stats routes
-		api.GET("/dashboard-stats", statHandler.GetAll)
-		api.GET("/dashboard-stats/:id", statHandler.GetByID)
-		api.POST("/dashboard-stats", statHandler.Create)
-		api.PUT("/dashboard-stats/:id", statHandler.Update)
-		api.DELETE("/dashboard-stats/:id", statHandler.Delete)
-
-		// Risks routes
-		api.GET("/risks", riskHandler.GetAll)
-		api.GET("/risks/:id", riskHandler.GetByID)
-		api.POST("/risks", riskHandler.Create)
-		api.PUT("/risks/:id", riskHandler.Update)
-		api.DELETE("/risks/:id", riskHandler.Delete)
-
-		// Opportunities routes
-		api.GET("/opportunities", oppHandler.GetAll)
-		api.GET("/opportunities/:id", oppHandler.GetByID)
-		api.POST("/opportunities", oppHandler.Create)
-		api.PUT("/opportunities/:id", oppHandler.Update)
-		api.DELETE("/opportunities/:id", oppHandler.Delete)
-
-		// Sentiment Trends routes
-		api.GET("/sentiment-trends", sentimentTrendHandler.GetAll)
-		api.GET("/sentiment-trends/:id", sentimentTrendHandler.GetByID)
-		api.POST("/sentiment-trends", sentimentTrendHandler.Create)
-		api.PUT("/sentiment-trends/:id", sentimentTrendHandler.Update)
-		api.DELETE("/sentiment-trends/:id", sentimentTrendHandler.Delete)
-
-		// Discussion Topics routes
-		api.GET("/discussion-topics", discussionTopicHandler.GetAll)
-		api.GET("/discussion-topics/:id", discussionTopicHandler.GetByID)
-		api.POST("/discussion-topics", discussionTopicHandler.Create)
-		api.PUT("/discussion-topics/:id", discussionTopicHandler.Update)
-		api.DELETE("/discussion-topics/:id", discussionTopicHandler.Delete)
-
-		// Competitive Analysis routes
-		api.GET("/competitive-analyses", competitiveAnalysisHandler.GetAll)
-		api.GET("/competitive-analyses/:id", competitiveAnalysisHandler.GetByID)
-		api.POST("/competitive-analyses", competitiveAnalysisHandler.Create)
-		api.PUT("/competitive-analyses/:id", competitiveAnalysisHandler.Update)
-		api.DELETE("/competitive-analyses/:id", competitiveAnalysisHandler.Delete)
-
-		// Conversation Clusters routes
-		api.GET("/conversation-clusters", conversationClusterHandler.GetAll)
-		api.GET("/conversation-clusters/:id", conversationClusterHandler.GetByID)
-		api.POST("/conversation-clusters", conversationClusterHandler.Create)
-		api.PUT("/conversation-clusters/:id", conversationClusterHandler.Update)
-		api.DELETE("/conversation-clusters/:id", conversationClusterHandler.Delete)
+	api.Use(idempotency.Middleware(idempotencyStore))
+	handlers := &handler.Handlers{
+		PriorityActions:      h,
+		DashboardStats:       statHandler,
+		Risks:                riskHandler,
+		Opportunities:        oppHandler,
+		SentimentTrends:      sentimentTrendHandler,
+		DiscussionTopics:     discussionTopicHandler,
+		CompetitiveAnalyses:  competitiveAnalysisHandler,
+		ConversationClusters: conversationClusterHandler,
+		Dashboard:            dashboardHandler,
+		Reports:              reportHandler,
+		Relations:            relationHandler,
+		Evidence:             evidenceHandler,
+		Anomalies:            anomalyHandler,
+		Admin:                adminHandler,
+		Snapshots:            snapshotHandler,
+		ReportSchedules:      reportScheduleHandler,
+	}
+	handlers.Register(api)
+
+	// Start the report scheduler
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	if cfg.SchedulerEnabled {
+		backgroundScheduler.Start(schedulerCtx)
+		slog.Info("Report scheduler started", "interval", cfg.SchedulerInterval.String())
 	}
 
 	// Start server
@@ -181,25 +287,44 @@ func main() {
 	// Graceful shutdown
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatal("Failed to start server:", err)
+			fatal("Failed to start server", err)
 		}
 	}()
 
-	log.Printf("Server started on port %s\n", cfg.Port)
+	slog.Info("Server started", "port", cfg.Port)
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	slog.Info("Shutting down server")
+
+	// Fail readiness first so load balancers stop routing new requests here
+	checker.ShutDown()
+	time.Sleep(cfg.ShutdownDelay)
 
 	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server forced to shutdown:", err)
+		fatal("Server forced to shutdown", err)
+	}
+
+	// Let report runs in progress finish sending
+	stopScheduler()
+	backgroundScheduler.Wait()
+
+	// Flush the spans still buffered
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
 	}
 
-	log.Println("Server exited")
+	slog.Info("Server exited")
+}
+
+// fatal logs err and exits. Like log.Fatal, it skips deferred calls.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,143 @@
+// Package anomaly flags unusual points in short daily series such as
+// sentiment percentages and mention counts.
+package anomaly
+
+import "math"
+
+// Options tune the detector. Zero values fall back to the defaults.
+type Options struct {
+	// Window is the number of preceding points each point is compared with.
+	Window int
+	// Threshold is the absolute z-score at which a point is anomalous.
+	Threshold float64
+	// Season removes a repeating pattern of this many points (7 for a weekly
+	// cycle in daily data) before scoring. Zero disables it.
+	Season int
+}
+
+const (
+	DefaultWindow    = 14
+	DefaultThreshold = 3.0
+
+	// minHistory is the fewest preceding points a point is scored against
+	minHistory = 5
+)
+
+func (o Options) withDefaults() Options {
+	if o.Window <= 0 {
+		o.Window = DefaultWindow
+	}
+	if o.Threshold <= 0 {
+		o.Threshold = DefaultThreshold
+	}
+	if o.Season < 0 {
+		o.Season = 0
+	}
+	return o
+}
+
+// Result describes one anomalous point of a series.
+type Result struct {
+	Index    int
+	Value    float64
+	Expected float64
+	ZScore   float64
+}
+
+// Direction is "spike" for a point above the expected value and "drop" for
+// one below it.
+func (r Result) Direction() string {
+	if r.Value < r.Expected {
+		return "drop"
+	}
+	return "spike"
+}
+
+// Detect scores every point against the mean and standard deviation of the
+// Window points before it (a rolling z-score) and returns the points whose
+// score reaches Threshold. With Season set, the average seasonal offset of
+// each phase is subtracted first, so a regular weekend dip is not flagged.
+// Points with fewer than five predecessors are never flagged.
+func Detect(values []float64, opts Options) []Result {
+	opts = opts.withDefaults()
+
+	seasonal := seasonalOffsets(values, opts.Season)
+	residuals := make([]float64, len(values))
+	for i, v := range values {
+		residuals[i] = v
+		if seasonal != nil {
+			residuals[i] -= seasonal[i%opts.Season]
+		}
+	}
+
+	var results []Result
+	for i := minHistory; i < len(residuals); i++ {
+		start := i - opts.Window
+		if start < 0 {
+			start = 0
+		}
+		mean, sd := meanStdDev(residuals[start:i])
+		if sd < 1e-9 {
+			continue
+		}
+		z := (residuals[i] - mean) / sd
+		if math.Abs(z) < opts.Threshold {
+			continue
+		}
+		expected := mean
+		if seasonal != nil {
+			expected += seasonal[i%opts.Season]
+		}
+		results = append(results, Result{Index: i, Value: values[i], Expected: expected, ZScore: z})
+	}
+	return results
+}
+
+// seasonalOffsets returns the average deviation of each phase from the
+// moving average of the season around it, or nil when the series is shorter
+// than two seasons.
+func seasonalOffsets(values []float64, season int) []float64 {
+	if season < 2 || len(values) < 2*season {
+		return nil
+	}
+
+	sums := make([]float64, season)
+	counts := make([]int, season)
+	half := season / 2
+	for i := half; i-half+season <= len(values); i++ {
+		trend, _ := meanStdDev(values[i-half : i-half+season])
+		sums[i%season] += values[i] - trend
+		counts[i%season]++
+	}
+
+	offsets := make([]float64, season)
+	var total float64
+	for phase := range offsets {
+		if counts[phase] > 0 {
+			offsets[phase] = sums[phase] / float64(counts[phase])
+		}
+		total += offsets[phase]
+	}
+	// Offsets of a season sum to zero, so they do not shift the level
+	for phase := range offsets {
+		offsets[phase] -= total / float64(season)
+	}
+	return offsets
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
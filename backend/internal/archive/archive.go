@@ -0,0 +1,176 @@
+// Package archive dumps the collections of a database to a portable file and
+// restores them.
+//
+// An archive is a tar file holding one gzip-compressed JSONL file per
+// collection, collections/<name>.jsonl.gz, with one document per line in
+// canonical MongoDB Extended JSON so ObjectIDs, dates and number types survive
+// the round trip. The last entry, manifest.json, lists every collection with
+// its document count and the SHA-256 of its uncompressed JSONL.
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// Format identifies the file as an archive of this package.
+	Format = "naradai-archive"
+	// Version is the archive layout this package writes. Restore reads it and
+	// every older version.
+	Version = 1
+
+	manifestName = "manifest.json"
+)
+
+// Skipped are collections that hold server state rather than content: locks,
+// idempotency replays, scheduler bookkeeping and the migration log, whose
+// version is recorded in the manifest instead.
+var Skipped = []string{
+	"idempotency_keys",
+	"scheduler_jobs",
+	"scheduler_locks",
+	"schema_migrations",
+	"schema_migrations_lock",
+}
+
+// Manifest describes an archive.
+type Manifest struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Database  string    `json:"database"`
+	// SchemaVersion is the highest migration applied to the database dumped
+	SchemaVersion int          `json:"schema_version"`
+	Collections   []Collection `json:"collections"`
+}
+
+// Collection is one collection in an archive.
+type Collection struct {
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	Documents int64  `json:"documents"`
+	SHA256    string `json:"sha256"`
+}
+
+// DumpOptions select what Dump writes.
+type DumpOptions struct {
+	// Collections limits the dump to these collections; empty dumps every
+	// collection except Skipped
+	Collections   []string
+	SchemaVersion int
+}
+
+// Dump writes the collections of db to w as an archive and returns its
+// manifest.
+func Dump(ctx context.Context, db *mongo.Database, w io.Writer, opts DumpOptions) (*Manifest, error) {
+	names := opts.Collections
+	if len(names) == 0 {
+		all, err := db.ListCollectionNames(ctx, bson.M{"type": "collection"})
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range all {
+			if !strings.HasPrefix(name, "system.") && !slices.Contains(Skipped, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+
+	manifest := &Manifest{
+		Format:        Format,
+		Version:       Version,
+		CreatedAt:     time.Now().UTC(),
+		Database:      db.Name(),
+		SchemaVersion: opts.SchemaVersion,
+		Collections:   []Collection{},
+	}
+	archive := tar.NewWriter(w)
+	for _, name := range names {
+		collection, err := dumpCollection(ctx, db.Collection(name), archive, manifest.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		manifest.Collections = append(manifest.Collections, *collection)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeEntry(archive, manifestName, data, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// dumpCollection compresses the collection into memory first, since a tar
+// entry needs its size up front.
+func dumpCollection(ctx context.Context, c *mongo.Collection, archive *tar.Writer, at time.Time) (*Collection, error) {
+	cursor, err := c.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	hash := sha256.New()
+	out := io.MultiWriter(gz, hash)
+
+	collection := &Collection{Name: c.Name(), File: "collections/" + c.Name() + ".jsonl.gz"}
+	for cursor.Next(ctx) {
+		line, err := bson.MarshalExtJSON(cursor.Current, true, false)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := out.Write(append(line, '\n')); err != nil {
+			return nil, err
+		}
+		collection.Documents++
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	collection.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	if err := writeEntry(archive, collection.File, compressed.Bytes(), at); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func writeEntry(archive *tar.Writer, name string, data []byte, at time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: at,
+		Format:  tar.FormatPAX,
+	}
+	if err := archive.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := archive.Write(data)
+	return err
+}
@@ -0,0 +1,317 @@
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// restoreBatch is the number of documents inserted per InsertMany.
+const restoreBatch = 500
+
+// maxLine caps the size of one document line; MongoDB documents are at most
+// 16MB, and Extended JSON adds some overhead.
+const maxLine = 64 << 20
+
+// RestoreOptions control how Restore writes documents.
+type RestoreOptions struct {
+	// Collections limits the restore to these collections; empty restores
+	// every collection in the archive
+	Collections []string
+	// RemapIDs gives every restored document a new ObjectID and rewrites
+	// references to it in the other restored documents, so the archive can be
+	// restored next to the data it came from
+	RemapIDs bool
+	// Replace deletes the documents of the restored collections first.
+	// Without it, or RemapIDs, those collections must be empty.
+	Replace bool
+	// SchemaVersion is the highest migration applied to the target database;
+	// an archive from a newer schema is refused
+	SchemaVersion int
+}
+
+// Restored is the number of documents restored into a collection.
+type Restored struct {
+	Collection string
+	Documents  int64
+}
+
+// Verify reads the whole archive and checks every collection against the
+// manifest, without writing anything.
+func Verify(r io.ReadSeeker) (*Manifest, error) {
+	manifest, _, err := verify(r, false)
+	return manifest, err
+}
+
+// Restore verifies the archive and then inserts its documents into db.
+// Nothing is written unless every checksum matches.
+func Restore(ctx context.Context, db *mongo.Database, r io.ReadSeeker, opts RestoreOptions) (*Manifest, []Restored, error) {
+	manifest, ids, err := verify(r, opts.RemapIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if manifest.SchemaVersion > opts.SchemaVersion {
+		return nil, nil, fmt.Errorf("archive is at schema version %d but the database is at %d; run migrate up first", manifest.SchemaVersion, opts.SchemaVersion)
+	}
+
+	selected := make(map[string]bool)
+	for _, c := range manifest.Collections {
+		if len(opts.Collections) == 0 || slices.Contains(opts.Collections, c.Name) {
+			selected[c.Name] = true
+		}
+	}
+	for _, name := range opts.Collections {
+		if !selected[name] {
+			return nil, nil, fmt.Errorf("collection %s is not in the archive", name)
+		}
+	}
+
+	if opts.Replace {
+		for name := range selected {
+			if _, err := db.Collection(name).DeleteMany(ctx, bson.M{}); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	} else if !opts.RemapIDs {
+		var nonEmpty []string
+		for name := range selected {
+			count, err := db.Collection(name).CountDocuments(ctx, bson.M{})
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if count > 0 {
+				nonEmpty = append(nonEmpty, name)
+			}
+		}
+		if len(nonEmpty) > 0 {
+			slices.Sort(nonEmpty)
+			return nil, nil, fmt.Errorf("collections %s are not empty; restore with replace or with remapped IDs", strings.Join(nonEmpty, ", "))
+		}
+	}
+
+	// References can only be remapped to documents restored alongside them
+	remapped := make(map[primitive.ObjectID]primitive.ObjectID)
+	for name, collectionIDs := range ids {
+		if selected[name] {
+			for _, id := range collectionIDs {
+				remapped[id] = primitive.NewObjectID()
+			}
+		}
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+	var restored []Restored
+	err = eachCollection(r, func(name string, lines *bufio.Scanner) error {
+		if !selected[name] {
+			return nil
+		}
+		result := Restored{Collection: name}
+		var batch []interface{}
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			if _, err := db.Collection(name).InsertMany(ctx, batch); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			result.Documents += int64(len(batch))
+			batch = batch[:0]
+			return nil
+		}
+		for lines.Scan() {
+			var doc bson.D
+			if err := bson.UnmarshalExtJSON(lines.Bytes(), true, &doc); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			if opts.RemapIDs {
+				remap(doc, remapped)
+			}
+			if batch = append(batch, doc); len(batch) == restoreBatch {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := lines.Err(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		err := flush()
+		restored = append(restored, result)
+		return err
+	})
+	return manifest, restored, err
+}
+
+// verify checks every collection file against the manifest. With collectIDs
+// it also returns the ObjectID _ids of each collection.
+func verify(r io.ReadSeeker, collectIDs bool) (*Manifest, map[string][]primitive.ObjectID, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, err
+	}
+
+	type digest struct {
+		documents int64
+		sha256    string
+	}
+	digests := make(map[string]digest)
+	ids := make(map[string][]primitive.ObjectID)
+	var manifest *Manifest
+	err := eachEntry(r, func(name string, entry io.Reader) error {
+		if name == manifestName {
+			manifest = &Manifest{}
+			return json.NewDecoder(entry).Decode(manifest)
+		}
+		collection, ok := collectionName(name)
+		if !ok {
+			return fmt.Errorf("unexpected file %s in archive", name)
+		}
+		return eachLine(entry, func(lines *bufio.Scanner) error {
+			hash := sha256.New()
+			var d digest
+			for lines.Scan() {
+				hash.Write(lines.Bytes())
+				hash.Write([]byte{'\n'})
+				d.documents++
+				if collectIDs {
+					var doc struct {
+						ID interface{} `bson:"_id"`
+					}
+					if err := bson.UnmarshalExtJSON(lines.Bytes(), true, &doc); err != nil {
+						return fmt.Errorf("%s line %d: %w", collection, d.documents, err)
+					}
+					if id, ok := doc.ID.(primitive.ObjectID); ok {
+						ids[collection] = append(ids[collection], id)
+					}
+				}
+			}
+			if err := lines.Err(); err != nil {
+				return fmt.Errorf("%s: %w", collection, err)
+			}
+			d.sha256 = hex.EncodeToString(hash.Sum(nil))
+			digests[collection] = d
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if manifest == nil {
+		return nil, nil, errors.New("archive has no manifest")
+	}
+	if manifest.Format != Format {
+		return nil, nil, fmt.Errorf("not an archive of this server (format %q)", manifest.Format)
+	}
+	if manifest.Version < 1 || manifest.Version > Version {
+		return nil, nil, fmt.Errorf("archive version %d is not supported; this server reads versions 1 to %d", manifest.Version, Version)
+	}
+	var errs []error
+	for _, c := range manifest.Collections {
+		d, ok := digests[c.Name]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s: missing from the archive", c.Name))
+		case d.sha256 != c.SHA256:
+			errs = append(errs, fmt.Errorf("%s: checksum mismatch", c.Name))
+		case d.documents != c.Documents:
+			errs = append(errs, fmt.Errorf("%s: %d documents, manifest lists %d", c.Name, d.documents, c.Documents))
+		}
+		delete(digests, c.Name)
+	}
+	for name := range digests {
+		errs = append(errs, fmt.Errorf("%s: not listed in the manifest", name))
+	}
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("archive is corrupt:\n%w", errors.Join(errs...))
+	}
+	return manifest, ids, nil
+}
+
+func collectionName(file string) (string, bool) {
+	name, ok := strings.CutPrefix(file, "collections/")
+	if !ok {
+		return "", false
+	}
+	return strings.CutSuffix(name, ".jsonl.gz")
+}
+
+// eachEntry calls fn with every file in the tar archive.
+func eachEntry(r io.Reader, fn func(name string, entry io.Reader) error) error {
+	archive := tar.NewReader(r)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(header.Name, archive); err != nil {
+			return err
+		}
+	}
+}
+
+// eachCollection calls fn with the document lines of every collection file.
+func eachCollection(r io.Reader, fn func(name string, lines *bufio.Scanner) error) error {
+	return eachEntry(r, func(file string, entry io.Reader) error {
+		name, ok := collectionName(file)
+		if !ok {
+			return nil
+		}
+		return eachLine(entry, func(lines *bufio.Scanner) error {
+			return fn(name, lines)
+		})
+	})
+}
+
+// eachLine decompresses a collection file and calls fn with a scanner over
+// its lines.
+func eachLine(entry io.Reader, fn func(lines *bufio.Scanner) error) error {
+	gz, err := gzip.NewReader(entry)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	lines := bufio.NewScanner(gz)
+	lines.Buffer(make([]byte, 0, 64<<10), maxLine)
+	return fn(lines)
+}
+
+// remap replaces every ObjectID in doc, at any depth, that has a new ID.
+func remap(value interface{}, ids map[primitive.ObjectID]primitive.ObjectID) interface{} {
+	switch v := value.(type) {
+	case primitive.ObjectID:
+		if id, ok := ids[v]; ok {
+			return id
+		}
+	case bson.D:
+		for i := range v {
+			v[i].Value = remap(v[i].Value, ids)
+		}
+	case bson.A:
+		for i := range v {
+			v[i] = remap(v[i], ids)
+		}
+	}
+	return value
+}
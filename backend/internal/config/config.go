@@ -1,35 +1,198 @@
 package config
 
 import (
+	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
 	Port               string
 	GinMode            string
+	LogLevel           slog.Level
 	MongoDBURI         string
 	MongoDBDatabase    string
+	MigrateOnStart     bool
 	CORSAllowedOrigins []string
 	CORSAllowedMethods []string
 	CORSAllowedHeaders []string
+	IdempotencyTTL     time.Duration
+	ReportBrandName    string
+	ReportBrandColor   string
+	ReportLogoPath     string
+	SchedulerEnabled   bool
+	SchedulerInterval  time.Duration
+	SnapshotCron       string
+	StatRefreshCron    string
+	SMTPHost           string
+	SMTPPort           int
+	SMTPUsername       string
+	SMTPPassword       string
+	SMTPFrom           string
+	ShutdownDelay      time.Duration
+	ReadinessTimeout   time.Duration
+	TracesExporter     string
+	OTLPEndpoint       string
+	ServiceName        string
+
+	// File is the config file the values were layered over, if any
+	File string
+	// sources records where each setting came from: "env", "file" or "default"
+	sources map[string]string
 }
 
-func Load() *Config {
-	return &Config{
-		Port:               getEnv("PORT", "8000"),
-		GinMode:            getEnv("GIN_MODE", "debug"),
-		MongoDBURI:         getEnv("MONGODB_URI", "mongodb://localhost:27017"),
-		MongoDBDatabase:    getEnv("MONGODB_DATABASE", "naradai"),
-		CORSAllowedOrigins: strings.Split(getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:5173,https://staging.teoremaintelligence.com,https://teoremaintelligence.com,http://127.0.0.1:8000,http://127.0.0.1:8080,https://api.staging.teoremaintelligence.com"), ","),
-		CORSAllowedMethods: strings.Split(getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"), ","),
-		CORSAllowedHeaders: strings.Split(getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"), ","),
+// Load reads the configuration from environment variables, falling back to
+// the YAML or TOML file named by CONFIG_FILE and then to the defaults. It
+// fails when the file cannot be read or any value is invalid, listing every
+// problem at once.
+func Load() (*Config, error) {
+	l := &loader{sources: map[string]string{}}
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		file, err := readFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+		l.file = file
+	}
+
+	cfg := &Config{
+		Port:               l.get("PORT", "8000"),
+		GinMode:            l.get("GIN_MODE", "debug"),
+		LogLevel:           l.getLevel("LOG_LEVEL", slog.LevelInfo),
+		MongoDBURI:         l.get("MONGODB_URI", "mongodb://localhost:27017"),
+		MongoDBDatabase:    l.get("MONGODB_DATABASE", "naradai"),
+		MigrateOnStart:     l.getBool("MIGRATE_ON_START", false),
+		CORSAllowedOrigins: l.getList("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:5173,http://127.0.0.1:5173,https://staging.teoremaintelligence.com,https://teoremaintelligence.com,http://127.0.0.1:8000,http://127.0.0.1:8080,https://api.staging.teoremaintelligence.com"),
+		CORSAllowedMethods: l.getList("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+		CORSAllowedHeaders: l.getList("CORS_ALLOWED_HEADERS", "Origin,Accept,Content-Type,Authorization,If-Match,If-None-Match,Idempotency-Key,X-Request-ID,traceparent,tracestate"),
+		IdempotencyTTL:     l.getDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		ReportBrandName:    l.get("REPORT_BRAND_NAME", "Naradai"),
+		ReportBrandColor:   l.get("REPORT_BRAND_COLOR", "#4F46E5"),
+		ReportLogoPath:     l.get("REPORT_LOGO_PATH", ""),
+		SchedulerEnabled:   l.getBool("SCHEDULER_ENABLED", true),
+		SchedulerInterval:  l.getDuration("SCHEDULER_INTERVAL", 30*time.Second),
+		SnapshotCron:       l.getCron("SNAPSHOT_CRON", "0 0 * * *"),
+		StatRefreshCron:    l.getCron("STAT_REFRESH_CRON", "*/15 * * * *"),
+		SMTPHost:           l.get("SMTP_HOST", ""),
+		SMTPPort:           l.getInt("SMTP_PORT", 587),
+		SMTPUsername:       l.get("SMTP_USERNAME", ""),
+		SMTPPassword:       l.get("SMTP_PASSWORD", ""),
+		SMTPFrom:           l.get("SMTP_FROM", "reports@naradai.local"),
+		ShutdownDelay:      l.getDuration("SHUTDOWN_DELAY", 5*time.Second),
+		ReadinessTimeout:   l.getDuration("READINESS_TIMEOUT", 2*time.Second),
+		TracesExporter:     l.get("OTEL_TRACES_EXPORTER", "none"),
+		OTLPEndpoint:       l.get("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
+		ServiceName:        l.get("OTEL_SERVICE_NAME", "naradai-backend"),
+		File:               os.Getenv("CONFIG_FILE"),
+		sources:            l.sources,
+	}
+
+	if err := cfg.Validate(l.errs...); err != nil {
+		return nil, err
 	}
+	return cfg, nil
 }
 
-func getEnv(key, defaultValue string) string {
+// loader resolves settings by name, collecting parse errors instead of
+// silently falling back to defaults.
+type loader struct {
+	file    map[string]string
+	sources map[string]string
+	errs    []error
+}
+
+func (l *loader) lookup(key string) (string, bool) {
 	if value := os.Getenv(key); value != "" {
+		l.sources[key] = "env"
+		return value, true
+	}
+	if value, ok := l.file[key]; ok && value != "" {
+		l.sources[key] = "file"
+		return value, true
+	}
+	l.sources[key] = "default"
+	return "", false
+}
+
+func (l *loader) get(key, defaultValue string) string {
+	if value, ok := l.lookup(key); ok {
 		return value
 	}
 	return defaultValue
 }
+
+// getList splits a comma-separated value, dropping blank entries.
+func (l *loader) getList(key, defaultValue string) []string {
+	var list []string
+	for _, item := range strings.Split(l.get(key, defaultValue), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+func (l *loader) getDuration(key string, defaultValue time.Duration) time.Duration {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not a duration such as 30s or 24h", key, value))
+		return defaultValue
+	}
+	return d
+}
+
+func (l *loader) getInt(key string, defaultValue int) int {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not a whole number", key, value))
+		return defaultValue
+	}
+	return n
+}
+
+func (l *loader) getBool(key string, defaultValue bool) bool {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not true or false", key, value))
+		return defaultValue
+	}
+	return b
+}
+
+func (l *loader) getLevel(key string, defaultValue slog.Level) slog.Level {
+	value, ok := l.lookup(key)
+	if !ok {
+		return defaultValue
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q must be debug, info, warn or error", key, value))
+		return defaultValue
+	}
+	return level
+}
+
+// getCron reads a cron expression, where "off" disables the job and yields
+// an empty string.
+func (l *loader) getCron(key, defaultValue string) string {
+	value := l.get(key, defaultValue)
+	if value == "off" {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// readFile parses a YAML or TOML config file into settings keyed like the
+// environment variables. Keys are matched case-insensitively and nested
+// tables are joined with underscores, so both of these set SMTP_HOST:
+//
+//	smtp_host: mail.example.com
+//
+//	smtp:
+//	  host: mail.example.com
+//
+// Lists become comma-separated values.
+func readFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settings := map[string]string{}
+	flatten(settings, "", raw)
+	return settings, nil
+}
+
+func flatten(settings map[string]string, prefix string, values map[string]interface{}) {
+	for key, value := range values {
+		name := strings.ToUpper(prefix + key)
+		switch value := value.(type) {
+		case map[string]interface{}:
+			flatten(settings, name+"_", value)
+		case []interface{}:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = fmt.Sprint(item)
+			}
+			settings[name] = strings.Join(items, ",")
+		case nil:
+		default:
+			settings[name] = fmt.Sprint(value)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	hexColorPattern = regexp.MustCompile(`^#(?:[0-9A-Fa-f]{3}|[0-9A-Fa-f]{6})$`)
+	headerPattern   = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+	httpMethods     = map[string]bool{
+		"GET": true, "HEAD": true, "POST": true, "PUT": true,
+		"PATCH": true, "DELETE": true, "OPTIONS": true,
+	}
+)
+
+// Validate checks every setting, returning all problems joined together so a
+// misconfigured deployment can be fixed in one go. Extra errors, such as
+// values that failed to parse, are reported alongside.
+func (c *Config) Validate(extra ...error) error {
+	errs := append([]error(nil), extra...)
+	fail := func(key, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		fail("PORT", "%q is not a port between 1 and 65535", c.Port)
+	}
+	switch c.GinMode {
+	case "debug", "release", "test":
+	default:
+		fail("GIN_MODE", "%q must be debug, release or test", c.GinMode)
+	}
+
+	if uri, err := url.Parse(c.MongoDBURI); err != nil || (uri.Scheme != "mongodb" && uri.Scheme != "mongodb+srv") || uri.Host == "" {
+		fail("MONGODB_URI", "must be a mongodb:// or mongodb+srv:// URI")
+	}
+	if c.MongoDBDatabase == "" || strings.ContainsAny(c.MongoDBDatabase, `/\. "$`) {
+		fail("MONGODB_DATABASE", "%q is not a valid database name", c.MongoDBDatabase)
+	}
+
+	if len(c.CORSAllowedOrigins) == 0 {
+		fail("CORS_ALLOWED_ORIGINS", "at least one origin is required")
+	}
+	for _, origin := range c.CORSAllowedOrigins {
+		uri, err := url.Parse(origin)
+		switch {
+		case origin == "*" || strings.Contains(origin, "*"):
+			fail("CORS_ALLOWED_ORIGINS", "wildcard %q cannot be used because credentials are allowed", origin)
+		case err != nil || (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "":
+			fail("CORS_ALLOWED_ORIGINS", "%q is not an http(s) origin such as https://example.com", origin)
+		case uri.Path != "" || uri.RawQuery != "" || uri.Fragment != "":
+			fail("CORS_ALLOWED_ORIGINS", "%q must not have a path", origin)
+		}
+	}
+	if len(c.CORSAllowedMethods) == 0 {
+		fail("CORS_ALLOWED_METHODS", "at least one method is required")
+	}
+	for _, method := range c.CORSAllowedMethods {
+		if !httpMethods[method] {
+			fail("CORS_ALLOWED_METHODS", "%q is not an HTTP method", method)
+		}
+	}
+	for _, header := range c.CORSAllowedHeaders {
+		if !headerPattern.MatchString(header) {
+			fail("CORS_ALLOWED_HEADERS", "%q is not a header name", header)
+		}
+	}
+
+	if c.IdempotencyTTL <= 0 {
+		fail("IDEMPOTENCY_TTL", "must be positive")
+	}
+	if c.SchedulerInterval <= 0 {
+		fail("SCHEDULER_INTERVAL", "must be positive")
+	}
+	for _, job := range []struct{ key, spec string }{
+		{"SNAPSHOT_CRON", c.SnapshotCron},
+		{"STAT_REFRESH_CRON", c.StatRefreshCron},
+	} {
+		if job.spec == "" {
+			continue
+		}
+		if _, err := cron.ParseStandard(job.spec); err != nil {
+			fail(job.key, "%q is not a cron expression (use \"off\" to disable): %v", job.spec, err)
+		}
+	}
+
+	if c.ReportBrandName == "" {
+		fail("REPORT_BRAND_NAME", "must not be empty")
+	}
+	if !hexColorPattern.MatchString(c.ReportBrandColor) {
+		fail("REPORT_BRAND_COLOR", "%q is not a hex color such as #4F46E5", c.ReportBrandColor)
+	}
+	if c.ReportLogoPath != "" {
+		if info, err := os.Stat(c.ReportLogoPath); err != nil || info.IsDir() {
+			fail("REPORT_LOGO_PATH", "%q is not a readable file", c.ReportLogoPath)
+		}
+	}
+
+	if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+		fail("SMTP_PORT", "%d is not a port between 1 and 65535", c.SMTPPort)
+	}
+	if _, err := mail.ParseAddress(c.SMTPFrom); err != nil {
+		fail("SMTP_FROM", "%q is not an email address", c.SMTPFrom)
+	}
+	if c.SMTPPassword != "" && c.SMTPUsername == "" {
+		fail("SMTP_USERNAME", "is required when SMTP_PASSWORD is set")
+	}
+
+	if c.ShutdownDelay < 0 {
+		fail("SHUTDOWN_DELAY", "must not be negative")
+	}
+	if c.ReadinessTimeout <= 0 {
+		fail("READINESS_TIMEOUT", "must be positive")
+	}
+
+	switch c.TracesExporter {
+	case "otlp", "stdout", "none":
+	default:
+		fail("OTEL_TRACES_EXPORTER", "%q must be otlp, stdout or none", c.TracesExporter)
+	}
+	if uri, err := url.Parse(c.OTLPEndpoint); c.TracesExporter == "otlp" && (err != nil || (uri.Scheme != "http" && uri.Scheme != "https") || uri.Host == "") {
+		fail("OTEL_EXPORTER_OTLP_ENDPOINT", "%q is not an http(s) URL such as http://localhost:4318", c.OTLPEndpoint)
+	}
+	if c.ServiceName == "" {
+		fail("OTEL_SERVICE_NAME", "must not be empty")
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return nil
+}
+
+// Setting is one entry of the effective configuration.
+type Setting struct {
+	Value  interface{} `json:"value"`
+	Source string      `json:"source"`
+}
+
+// Redacted returns the effective configuration keyed by environment variable,
+// with where each value came from. Secrets are masked: the SMTP password is
+// hidden and credentials are removed from the MongoDB URI.
+func (c *Config) Redacted() map[string]Setting {
+	mongoURI := c.MongoDBURI
+	if uri, err := url.Parse(c.MongoDBURI); err == nil && uri.User != nil {
+		uri.User = url.UserPassword("redacted", "redacted")
+		mongoURI = uri.String()
+	}
+	smtpPassword := ""
+	if c.SMTPPassword != "" {
+		smtpPassword = "redacted"
+	}
+	cronValue := func(spec string) string {
+		if spec == "" {
+			return "off"
+		}
+		return spec
+	}
+
+	values := map[string]interface{}{
+		"PORT":                        c.Port,
+		"GIN_MODE":                    c.GinMode,
+		"LOG_LEVEL":                   c.LogLevel.String(),
+		"MONGODB_URI":                 mongoURI,
+		"MONGODB_DATABASE":            c.MongoDBDatabase,
+		"MIGRATE_ON_START":            c.MigrateOnStart,
+		"CORS_ALLOWED_ORIGINS":        c.CORSAllowedOrigins,
+		"CORS_ALLOWED_METHODS":        c.CORSAllowedMethods,
+		"CORS_ALLOWED_HEADERS":        c.CORSAllowedHeaders,
+		"IDEMPOTENCY_TTL":             c.IdempotencyTTL.String(),
+		"REPORT_BRAND_NAME":           c.ReportBrandName,
+		"REPORT_BRAND_COLOR":          c.ReportBrandColor,
+		"REPORT_LOGO_PATH":            c.ReportLogoPath,
+		"SCHEDULER_ENABLED":           c.SchedulerEnabled,
+		"SCHEDULER_INTERVAL":          c.SchedulerInterval.String(),
+		"SNAPSHOT_CRON":               cronValue(c.SnapshotCron),
+		"STAT_REFRESH_CRON":           cronValue(c.StatRefreshCron),
+		"SMTP_HOST":                   c.SMTPHost,
+		"SMTP_PORT":                   c.SMTPPort,
+		"SMTP_USERNAME":               c.SMTPUsername,
+		"SMTP_PASSWORD":               smtpPassword,
+		"SMTP_FROM":                   c.SMTPFrom,
+		"SHUTDOWN_DELAY":              c.ShutdownDelay.String(),
+		"READINESS_TIMEOUT":           c.ReadinessTimeout.String(),
+		"OTEL_TRACES_EXPORTER":        c.TracesExporter,
+		"OTEL_EXPORTER_OTLP_ENDPOINT": c.OTLPEndpoint,
+		"OTEL_SERVICE_NAME":           c.ServiceName,
+	}
+
+	settings := make(map[string]Setting, len(values))
+	for key, value := range values {
+		source := c.sources[key]
+		if source == "" {
+			source = "default"
+		}
+		settings[key] = Setting{Value: value, Source: source}
+	}
+	return settings
+}
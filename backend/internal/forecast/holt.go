@@ -0,0 +1,158 @@
+// Package forecast projects short daily series forward with exponential
+// smoothing (Holt's linear trend method, or additive Holt-Winters when the
+// series is long enough to carry a seasonal pattern).
+package forecast
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrTooShort is returned for a series with fewer than MinPoints values.
+var ErrTooShort = errors.New("series too short to forecast")
+
+// MinPoints is the shortest series Forecast accepts.
+const MinPoints = 4
+
+const (
+	MethodHolt        = "holt"
+	MethodHoltWinters = "holt-winters"
+
+	DefaultLevel = 0.95
+)
+
+// Options tune the forecast. Zero values fall back to the defaults.
+type Options struct {
+	// Season is the length of the repeating pattern, e.g. 7 for weekly
+	// seasonality in daily data. It is used only when the series covers at
+	// least two seasons.
+	Season int
+	// Level is the coverage of the confidence interval, between 0 and 1.
+	Level float64
+}
+
+// Point is one projected value with its confidence interval.
+type Point struct {
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+// Result holds the projection and the smoothing parameters that were fitted.
+type Result struct {
+	Method string
+	Alpha  float64
+	Beta   float64
+	Gamma  float64
+	Points []Point
+}
+
+// Forecast projects values horizon steps ahead. The smoothing parameters are
+// chosen by a grid search that minimises the one-step-ahead squared error,
+// and the interval widens with the square root of the horizon around the
+// spread of those errors.
+func Forecast(values []float64, horizon int, opts Options) (*Result, error) {
+	if len(values) < MinPoints {
+		return nil, ErrTooShort
+	}
+	if opts.Level <= 0 || opts.Level >= 1 {
+		opts.Level = DefaultLevel
+	}
+	season := opts.Season
+	if season < 2 || len(values) < 2*season {
+		season = 0
+	}
+
+	grid := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9}
+	gammas := []float64{0}
+	if season > 0 {
+		gammas = grid
+	}
+
+	var best *fit
+	for _, alpha := range grid {
+		for _, beta := range grid {
+			for _, gamma := range gammas {
+				f := smooth(values, season, alpha, beta, gamma)
+				if best == nil || f.sse < best.sse {
+					best = f
+				}
+			}
+		}
+	}
+
+	result := &Result{Method: MethodHolt, Alpha: best.alpha, Beta: best.beta}
+	if season > 0 {
+		result.Method = MethodHoltWinters
+		result.Gamma = best.gamma
+	}
+
+	sigma := math.Sqrt(best.sse / float64(best.errors))
+	z := math.Sqrt2 * math.Erfinv(opts.Level)
+	result.Points = make([]Point, horizon)
+	for h := 1; h <= horizon; h++ {
+		value := best.level + float64(h)*best.trend
+		if season > 0 {
+			value += best.seasonal[(len(values)+h-1)%season]
+		}
+		spread := z * sigma * math.Sqrt(float64(h))
+		result.Points[h-1] = Point{Value: value, Lower: value - spread, Upper: value + spread}
+	}
+	return result, nil
+}
+
+type fit struct {
+	alpha, beta, gamma float64
+	level, trend       float64
+	seasonal           []float64
+	sse                float64
+	errors             int
+}
+
+// smooth runs the recursions over values and records the state after the
+// last point together with the one-step-ahead squared error.
+func smooth(values []float64, season int, alpha, beta, gamma float64) *fit {
+	f := &fit{alpha: alpha, beta: beta, gamma: gamma}
+
+	start := 1
+	f.level = values[0]
+	f.trend = values[1] - values[0]
+	if season > 0 {
+		// Initialise from the first two seasons: the level is the mean of the
+		// first, the trend the average per-step change between them, and the
+		// seasonal offsets the deviations within the first.
+		var first, second float64
+		for i := 0; i < season; i++ {
+			first += values[i]
+			second += values[season+i]
+		}
+		first /= float64(season)
+		second /= float64(season)
+		f.level = first
+		f.trend = (second - first) / float64(season)
+		f.seasonal = make([]float64, season)
+		for i := 0; i < season; i++ {
+			f.seasonal[i] = values[i] - first
+		}
+		start = season
+	}
+
+	for i := start; i < len(values); i++ {
+		var offset float64
+		if season > 0 {
+			offset = f.seasonal[i%season]
+		}
+		predicted := f.level + f.trend + offset
+		err := values[i] - predicted
+		f.sse += err * err
+		f.errors++
+
+		previous := f.level
+		f.level = alpha*(values[i]-offset) + (1-alpha)*(f.level+f.trend)
+		f.trend = beta*(f.level-previous) + (1-beta)*f.trend
+		if season > 0 {
+			f.seasonal[i%season] = gamma*(values[i]-f.level) + (1-gamma)*offset
+		}
+	}
+	return f
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/config"
+)
+
+type AdminHandler struct {
+	config *config.Config
+}
+
+func NewAdminHandler(cfg *config.Config) *AdminHandler {
+	return &AdminHandler{config: cfg}
+}
+
+// Config handles GET /api/v1/admin/config, returning the effective
+// configuration with secrets redacted and the source of each value.
+func (h *AdminHandler) Config(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"file":     h.config.File,
+			"settings": h.config.Redacted(),
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/anomaly"
+	"naradai-backend/internal/service"
+)
+
+type AnomalyHandler struct {
+	service *service.AnomalyService
+}
+
+func NewAnomalyHandler(svc *service.AnomalyService) *AnomalyHandler {
+	return &AnomalyHandler{service: svc}
+}
+
+// GetAll handles GET /api/v1/anomalies?source=sentiment_trends&window=14&threshold=3&season=7
+func (h *AnomalyHandler) GetAll(c *gin.Context) {
+	source := c.Query("source")
+	if source != "" && source != "sentiment_trends" && source != "dashboard_stats" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "source must be sentiment_trends or dashboard_stats",
+		})
+		return
+	}
+
+	window, _ := strconv.Atoi(c.Query("window"))
+	threshold, _ := strconv.ParseFloat(c.Query("threshold"), 64)
+	season, _ := strconv.Atoi(c.Query("season"))
+
+	anomalies, err := h.service.List(c.Request.Context(), source, anomaly.Options{
+		Window:    window,
+		Threshold: threshold,
+		Season:    season,
+	})
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to detect anomalies",
+		})
+		return
+	}
+
+	data := make([]map[string]interface{}, len(anomalies))
+	for i, a := range anomalies {
+		data[i] = a.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   len(data),
+	})
+}
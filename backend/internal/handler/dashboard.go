@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/report"
+	"naradai-backend/internal/service"
+	"naradai-backend/internal/tabular"
+)
+
+type DashboardHandler struct {
+	service *service.DashboardService
+}
+
+func NewDashboardHandler(svc *service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{service: svc}
+}
+
+// Export handles GET /api/v1/dashboard/export?format=xlsx|json
+func (h *DashboardHandler) Export(c *gin.Context) {
+	format, err := tabular.ParseFormat(c.DefaultQuery("format", "xlsx"))
+	if err != nil || format == tabular.CSV {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "format must be xlsx or json",
+		})
+		return
+	}
+
+	dashboard, err := h.service.Load(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to load dashboard",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("dashboard-%s.%s", dashboard.GeneratedAt.Format("2006-01-02"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	if format == tabular.JSON {
+		c.JSON(http.StatusOK, dashboard)
+		return
+	}
+
+	book, err := report.Workbook(dashboard, nil)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to build dashboard workbook",
+		})
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Status(http.StatusOK)
+	if _, err := book.WriteTo(c.Writer); err != nil {
+		slog.ErrorContext(c.Request.Context(), "dashboard export failed mid-stream", "error", err)
+		c.Abort()
+	}
+}
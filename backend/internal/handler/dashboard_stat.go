@@ -1,193 +1,246 @@
 package handler
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"naradai-backend/internal/models"
+	"naradai-backend/internal/resource"
 	"naradai-backend/internal/service"
 )
 
+// DashboardStatHandler serves dashboard stats and their time series.
 type DashboardStatHandler struct {
+	*resource.Handler[models.DashboardStat, *models.DashboardStat]
 	service *service.DashboardStatService
 }
 
 func NewDashboardStatHandler(svc *service.DashboardStatService) *DashboardStatHandler {
-	return &DashboardStatHandler{service: svc}
+	h := &DashboardStatHandler{
+		Handler: resource.NewHandler(svc.Service),
+		service: svc,
+	}
+	// A live stat changes without a new version, so it is never served as 304
+	h.Cacheable = func(stat *models.DashboardStat) bool {
+		return stat.Binding == nil || !stat.Binding.Live
+	}
+	return h
 }
 
-// GetAll handles GET /api/v1/dashboard-stats
-func (h *DashboardStatHandler) GetAll(c *gin.Context) {
-	// Parse query parameters
-	isActive := c.Query("is_active")
-	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
-	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+// Register adds the dashboard stat routes to api, the /api/v1 group.
+func (h *DashboardStatHandler) Register(api gin.IRouter) {
+	h.Handler.Register(api)
+	api.GET("/dashboard-stats/:id/series", h.Series)
+	api.GET("/dashboard-stats/:id/forecast", h.Forecast)
+	api.POST("/dashboard-stats/:id/points", h.RecordPoints)
+}
 
-	// Build filter
-	filter := bson.M{}
-	if isActive == "true" {
-		filter["is_active"] = true
-	} else if isActive == "false" {
-		filter["is_active"] = false
+// RecordPoints handles POST /api/v1/dashboard-stats/:id/points. The body is
+// a single {"value", "at"} point or an array of them; a point without "at" is
+// recorded at the current time, and a point at an existing time replaces it.
+func (h *DashboardStatHandler) RecordPoints(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Failed to read request body",
+		})
+		return
 	}
 
-	stats, total, err := h.service.GetAll(c.Request.Context(), filter, limit, offset)
+	var points []models.StatPoint
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		err = json.Unmarshal(body, &points)
+	} else {
+		var point models.StatPoint
+		err = json.Unmarshal(body, &point)
+		points = []models.StatPoint{point}
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Failed to fetch dashboard stats",
+			"error":   "Invalid request body: " + err.Error(),
 		})
 		return
 	}
-
-	// Convert to response format
-	data := make([]map[string]interface{}, len(stats))
-	for i, stat := range stats {
-		data[i] = stat.ToResponse()
+	if len(points) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "At least one point is required",
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    data,
-		"total":   total,
-	})
-}
-
-// GetByID handles GET /api/v1/dashboard-stats/:id
-func (h *DashboardStatHandler) GetByID(c *gin.Context) {
-	id := c.Param("id")
-
-	stat, err := h.service.GetByID(c.Request.Context(), id)
+	stat, err := h.service.RecordPoints(c.Request.Context(), c.Param("id"), points)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
+		if respondStatLookupError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrInvalidPoint) {
+			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
-				"error":   "Dashboard stat not found",
+				"error":   "Failed to record points: " + err.Error(),
 			})
 			return
 		}
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"error":   "Failed to fetch dashboard stat",
+			"error":   "Failed to record points",
 		})
 		return
 	}
 
+	resource.SetETag(c, stat.ID, stat.Version)
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
+		"message": "Points recorded successfully",
 		"data":    stat.ToResponse(),
 	})
 }
 
-// Create handles POST /api/v1/dashboard-stats
-func (h *DashboardStatHandler) Create(c *gin.Context) {
-	var stat models.DashboardStat
-	if err := c.ShouldBindJSON(&stat); err != nil {
+// Series handles GET /api/v1/dashboard-stats/:id/series?from=2024-01-01&to=2024-03-31&limit=90.
+// from and to accept a date or an RFC 3339 time; a date in to includes the
+// whole day.
+func (h *DashboardStatHandler) Series(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "90"), 10, 64)
+	if limit <= 0 || limit > 1000 {
+		limit = 90
+	}
+
+	from, err := parseSeriesTime(c.Query("from"), false)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Invalid request body: " + err.Error(),
+			"error":   "Invalid from: " + err.Error(),
 		})
 		return
 	}
-
-	// Validate
-	if err := h.service.Validate(&stat); err != nil {
+	to, err := parseSeriesTime(c.Query("to"), true)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Validation failed: " + err.Error(),
+			"error":   "Invalid to: " + err.Error(),
 		})
 		return
 	}
 
-	if err := h.service.Create(c.Request.Context(), &stat); err != nil {
+	stat, points, err := h.service.Series(c.Request.Context(), c.Param("id"), from, to, limit)
+	if err != nil {
+		if respondStatLookupError(c, err) {
+			return
+		}
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"error":   "Failed to create dashboard stat",
+			"error":   "Failed to fetch dashboard stat series",
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	data := make([]map[string]interface{}, len(points))
+	for i, point := range points {
+		data[i] = map[string]interface{}{
+			"at":        point.At.Format(time.RFC3339),
+			"value":     point.Value,
+			"formatted": service.FormatStatValue(point.Value, stat.Unit, stat.Currency),
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Dashboard stat created successfully",
-		"data":    stat.ToResponse(),
+		"data": gin.H{
+			"stat_id":  stat.ID.Hex(),
+			"label":    stat.Label,
+			"unit":     stat.Unit,
+			"currency": stat.Currency,
+			"value":    stat.Value,
+			"change":   stat.Change,
+			"trend":    stat.Trend,
+			"points":   data,
+		},
+		"total": len(data),
 	})
 }
 
-// Update handles PUT /api/v1/dashboard-stats/:id
-func (h *DashboardStatHandler) Update(c *gin.Context) {
-	id := c.Param("id")
-
-	var stat models.DashboardStat
-	if err := c.ShouldBindJSON(&stat); err != nil {
+// respondStatLookupError writes the response for a stat id that is not an
+// ObjectID or names no stat, and reports whether it did.
+func respondStatLookupError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, primitive.ErrInvalidHex):
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
-			"error":   "Invalid request body: " + err.Error(),
+			"error":   "Invalid id",
 		})
-		return
-	}
-
-	// Validate
-	if err := h.service.Validate(&stat); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
+	case errors.Is(err, resource.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
 			"success": false,
-			"error":   "Validation failed: " + err.Error(),
+			"error":   "Dashboard stat not found",
 		})
-		return
+	default:
+		return false
 	}
+	return true
+}
 
-	if err := h.service.Update(c.Request.Context(), id, &stat); err != nil {
-		if err.Error() == "dashboard stat not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"success": false,
-				"error":   "Dashboard stat not found",
-			})
-			return
+// parseSeriesTime parses a YYYY-MM-DD date or an RFC 3339 time. With
+// endOfDay, a date is moved to the last second of that day.
+func parseSeriesTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay {
+			t = t.Add(24*time.Hour - time.Second)
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update dashboard stat",
-		})
-		return
+		return t, nil
 	}
-
-	// Fetch updated stat
-	updatedStat, _ := h.service.GetByID(c.Request.Context(), id)
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Dashboard stat updated successfully",
-		"data":    updatedStat.ToResponse(),
-	})
+	return time.Parse(time.RFC3339, value)
 }
 
-// Delete handles DELETE /api/v1/dashboard-stats/:id
-func (h *DashboardStatHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
+// Forecast handles GET /api/v1/dashboard-stats/:id/forecast?days=14&season=7&level=0.95
+func (h *DashboardStatHandler) Forecast(c *gin.Context) {
+	days, opts, ok := forecastQuery(c)
+	if !ok {
+		return
+	}
 
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
-		if err.Error() == "dashboard stat not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"success": false,
-				"error":   "Dashboard stat not found",
-			})
+	result, err := h.service.Forecast(c.Request.Context(), c.Param("id"), days, opts)
+	if err != nil {
+		if respondStatLookupError(c, err) {
+			return
+		}
+		if errors.Is(err, service.ErrTooShort) {
+			respondTooShort(c)
 			return
 		}
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"error":   "Failed to delete dashboard stat",
+			"error":   "Failed to forecast dashboard stat",
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Dashboard stat deleted successfully",
+		"data": gin.H{
+			"stat_id":  result.Stat.ID.Hex(),
+			"label":    result.Stat.Label,
+			"unit":     result.Stat.Unit,
+			"method":   result.Method,
+			"level":    result.Level,
+			"history":  result.History,
+			"forecast": result.Forecast,
+		},
 	})
 }
-
-
-
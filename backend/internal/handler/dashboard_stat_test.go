@@ -0,0 +1,161 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDashboardStatPoints(t *testing.T) {
+	api := newTestAPI(t)
+	stat := api.create("/dashboard-stats", resourceCases[1].item(1))
+	path := "/dashboard-stats/" + stat["id"].(string)
+
+	expect(t, api.do(http.MethodPost, path+"/points", `[]`), http.StatusBadRequest)
+	expect(t, api.do(http.MethodPost, path+"/points", `{"value": "high"}`), http.StatusBadRequest)
+	expect(t, api.do(http.MethodPost, "/dashboard-stats/65f1a0000000000000000000/points", map[string]float64{"value": 1}), http.StatusNotFound)
+	expect(t, api.do(http.MethodPost, "/dashboard-stats/not-an-id/points", map[string]float64{"value": 1}), http.StatusBadRequest)
+
+	rec := api.do(http.MethodPost, path+"/points", []map[string]interface{}{
+		{"value": 10000, "at": "2024-05-01T00:00:00Z"},
+		{"value": 12400, "at": "2024-05-02T00:00:00Z"},
+	})
+	recorded := expect(t, rec, http.StatusOK).object(t)
+	if recorded["value"] != "12.4K" || recorded["change"] != "+24%" || recorded["trend"] != "up" || recorded["numeric_value"] != 12400.0 {
+		t.Fatalf("computed value = %v, change = %v, trend = %v, numeric_value = %v",
+			recorded["value"], recorded["change"], recorded["trend"], recorded["numeric_value"])
+	}
+
+	// A point at an existing time replaces it
+	recorded = expect(t, api.do(http.MethodPost, path+"/points", map[string]interface{}{"value": 9000, "at": "2024-05-02T00:00:00Z"}), http.StatusOK).object(t)
+	if recorded["value"] != "9K" || recorded["trend"] != "down" {
+		t.Fatalf("after replacing a point value = %v, trend = %v", recorded["value"], recorded["trend"])
+	}
+
+	series := expect(t, api.do(http.MethodGet, path+"/series", nil), http.StatusOK)
+	points, ok := series.object(t)["points"].([]interface{})
+	if !ok || series.Total != 2 || len(points) != 2 {
+		t.Fatalf("series total = %d, points = %v", series.Total, series.object(t)["points"])
+	}
+	if first := points[0].(map[string]interface{}); first["at"] != "2024-05-01T00:00:00Z" || first["formatted"] != "10K" {
+		t.Fatalf("first point %v, want the oldest", first)
+	}
+
+	series = expect(t, api.do(http.MethodGet, path+"/series?from=2024-05-02&to=2024-05-02", nil), http.StatusOK)
+	if series.Total != 1 {
+		t.Fatalf("series for one day has %d points, want 1", series.Total)
+	}
+	expect(t, api.do(http.MethodGet, path+"/series?from=yesterday", nil), http.StatusBadRequest)
+	expect(t, api.do(http.MethodGet, "/dashboard-stats/65f1a0000000000000000000/series", nil), http.StatusNotFound)
+	expect(t, api.do(http.MethodGet, "/dashboard-stats/not-an-id/series", nil), http.StatusBadRequest)
+
+	// A first point, or one repeating the last value, leaves the stat flat
+	stat = api.create("/dashboard-stats", resourceCases[1].item(2))
+	path = "/dashboard-stats/" + stat["id"].(string)
+	for _, at := range []string{"2024-05-01T00:00:00Z", "2024-05-02T00:00:00Z"} {
+		recorded = expect(t, api.do(http.MethodPost, path+"/points", map[string]interface{}{"value": 500, "at": at}), http.StatusOK).object(t)
+		if recorded["change"] != "0%" || recorded["trend"] != "flat" {
+			t.Fatalf("unchanged stat change = %v, trend = %v at %s", recorded["change"], recorded["trend"], at)
+		}
+	}
+}
+
+func TestDashboardStatForecast(t *testing.T) {
+	api := newTestAPI(t)
+	stat := api.create("/dashboard-stats", resourceCases[1].item(1))
+	path := "/dashboard-stats/" + stat["id"].(string)
+
+	// Forecasts use the last year of points
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -10)
+	points := make([]map[string]interface{}, 0, 10)
+	for day := 0; day < 3; day++ {
+		points = append(points, map[string]interface{}{"value": 100 + 10*day, "at": start.AddDate(0, 0, day)})
+	}
+	expect(t, api.do(http.MethodPost, path+"/points", points), http.StatusOK)
+	expect(t, api.do(http.MethodGet, path+"/forecast", nil), http.StatusUnprocessableEntity)
+
+	for day := 3; day < 10; day++ {
+		points = append(points, map[string]interface{}{"value": 100 + 10*day, "at": start.AddDate(0, 0, day)})
+	}
+	expect(t, api.do(http.MethodPost, path+"/points", points), http.StatusOK)
+	result := expect(t, api.do(http.MethodGet, path+"/forecast?days=5", nil), http.StatusOK).object(t)
+	if forecast, _ := result["forecast"].([]interface{}); len(forecast) != 5 {
+		t.Fatalf("forecast has %d days, want 5: %v", len(forecast), result["forecast"])
+	}
+	if history, _ := result["history"].([]interface{}); len(history) != 10 {
+		t.Fatalf("history has %d points, want 10", len(history))
+	}
+
+	expect(t, api.do(http.MethodGet, path+"/forecast?days=0", nil), http.StatusBadRequest)
+	expect(t, api.do(http.MethodGet, "/dashboard-stats/65f1a0000000000000000000/forecast", nil), http.StatusNotFound)
+	expect(t, api.do(http.MethodGet, "/dashboard-stats/not-an-id/forecast", nil), http.StatusBadRequest)
+}
+
+func TestDashboardStatBinding(t *testing.T) {
+	api := newTestAPI(t)
+	api.create("/conversation-clusters", resourceCases[7].item(1))
+	api.create("/conversation-clusters", resourceCases[7].item(2))
+
+	expect(t, api.do(http.MethodPost, "/dashboard-stats", map[string]interface{}{
+		"label":   "Mentions",
+		"icon":    "MessageSquare",
+		"binding": map[string]interface{}{"metric": "follower_count"},
+	}), http.StatusBadRequest)
+
+	stat := api.create("/dashboard-stats", map[string]interface{}{
+		"label":   "Mentions",
+		"icon":    "MessageSquare",
+		"binding": map[string]interface{}{"metric": "mention_count", "live": true},
+	})
+	if stat["value"] != "4.6K" {
+		t.Fatalf("bound stat value = %v, want 4.6K", stat["value"])
+	}
+
+	api.create("/conversation-clusters", resourceCases[7].item(3))
+	got := expect(t, api.do(http.MethodGet, fmt.Sprintf("/dashboard-stats/%s", stat["id"]), nil), http.StatusOK).object(t)
+	if got["value"] != "6.9K" {
+		t.Fatalf("live stat value = %v, want 6.9K", got["value"])
+	}
+	if rec := api.do(http.MethodGet, "/dashboard-stats", nil); rec.Header().Get("ETag") != "" {
+		t.Fatalf("list holding a live stat has ETag %q", rec.Header().Get("ETag"))
+	}
+
+	// An upsert merges the row onto the stat as stored, not as resolved live
+	body := expect(t, api.do(http.MethodPost, "/dashboard-stats/import?format=json&upsert=true", []map[string]interface{}{
+		{"label": "Mentions", "icon": "Users"},
+	}), http.StatusOK).object(t)
+	if body["updated"] != 1.0 {
+		t.Fatalf("upsert report %v", body)
+	}
+	got = expect(t, api.do(http.MethodGet, fmt.Sprintf("/dashboard-stats/%s", stat["id"]), nil), http.StatusOK).object(t)
+	binding, _ := got["binding"].(map[string]interface{})
+	if got["icon"] != "Users" || got["value"] != "6.9K" || binding["live"] != true {
+		t.Fatalf("live stat after upsert %v", got)
+	}
+}
+
+func TestSentimentTrendForecast(t *testing.T) {
+	api := newTestAPI(t)
+	short := api.create("/sentiment-trends", resourceCases[4].item(1))
+	expect(t, api.do(http.MethodGet, "/sentiment-trends/"+short["id"].(string)+"/forecast", nil), http.StatusUnprocessableEntity)
+
+	item := resourceCases[4].item(2)
+	days := make([]map[string]interface{}, 8)
+	for i := range days {
+		days[i] = map[string]interface{}{
+			"date":     fmt.Sprintf("2024-05-%02d", i+1),
+			"positive": 55 + float64(i),
+			"negative": 25 - float64(i),
+		}
+	}
+	item["trend_data"] = days
+	trend := api.create("/sentiment-trends", item)
+
+	result := expect(t, api.do(http.MethodGet, "/sentiment-trends/"+trend["id"].(string)+"/forecast?days=3", nil), http.StatusOK).object(t)
+	if result["forecast"] == nil || result["method"] == "" {
+		t.Fatalf("forecast result %v", result)
+	}
+	expect(t, api.do(http.MethodGet, "/sentiment-trends/65f1a0000000000000000000/forecast", nil), http.StatusNotFound)
+	expect(t, api.do(http.MethodGet, "/sentiment-trends/not-an-id/forecast", nil), http.StatusBadRequest)
+}
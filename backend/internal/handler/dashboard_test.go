@@ -0,0 +1,71 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDashboardExport(t *testing.T) {
+	api := newTestAPI(t)
+	for _, rc := range resourceCases {
+		api.create(rc.path, rc.item(1))
+	}
+
+	rec := api.do(http.MethodGet, "/dashboard/export?format=json", nil)
+	var dashboard map[string]json.RawMessage
+	if err := json.Unmarshal(expectRaw(t, rec, http.StatusOK), &dashboard); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"dashboard_stats", "priority_actions", "risks", "opportunities", "sentiment_trends", "discussion_topics", "competitive_analyses", "conversation_clusters"} {
+		var items []interface{}
+		if err := json.Unmarshal(dashboard[key], &items); err != nil || len(items) != 1 {
+			t.Errorf("exported %s = %s", key, dashboard[key])
+		}
+	}
+
+	rec = api.do(http.MethodGet, "/dashboard/export", nil)
+	if body := expectRaw(t, rec, http.StatusOK); !bytes.HasPrefix(body, []byte("PK")) {
+		t.Fatalf("xlsx export is not a zip archive: %q", body[:min(len(body), 16)])
+	}
+	expect(t, api.do(http.MethodGet, "/dashboard/export?format=csv", nil), http.StatusBadRequest)
+}
+
+func TestExecutiveReport(t *testing.T) {
+	api := newTestAPI(t)
+	for _, rc := range resourceCases {
+		api.create(rc.path, rc.item(1))
+	}
+
+	rec := api.do(http.MethodGet, "/reports/executive.pdf?period=2024-05", nil)
+	if body := expectRaw(t, rec, http.StatusOK); !bytes.HasPrefix(body, []byte("%PDF")) {
+		t.Fatalf("report is not a PDF: %q", body[:min(len(body), 16)])
+	}
+	if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, "executive-report-2024-05-01.pdf") {
+		t.Fatalf("Content-Disposition = %q", got)
+	}
+	expect(t, api.do(http.MethodGet, "/reports/executive.pdf?period=may", nil), http.StatusBadRequest)
+}
+
+func TestAnomalies(t *testing.T) {
+	api := newTestAPI(t)
+	api.create("/sentiment-trends", resourceCases[4].item(1))
+
+	expect(t, api.do(http.MethodGet, "/anomalies", nil), http.StatusOK)
+	expect(t, api.do(http.MethodGet, "/anomalies?source=dashboard_stats", nil), http.StatusOK)
+	expect(t, api.do(http.MethodGet, "/anomalies?source=risks", nil), http.StatusBadRequest)
+}
+
+func TestAdminConfig(t *testing.T) {
+	api := newTestAPI(t)
+	rec := api.do(http.MethodGet, "/admin/config", nil)
+	settings := expect(t, rec, http.StatusOK).object(t)["settings"].(map[string]interface{})
+	if settings["PORT"] == nil {
+		t.Fatalf("settings %v", settings)
+	}
+	if strings.Contains(rec.Body.String(), "secret") {
+		t.Fatalf("config exposes the MongoDB password: %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/service"
+)
+
+// EvidenceHandler serves the evidence routes of every resource that carries
+// evidence; each method returns the handler for one resource.
+type EvidenceHandler struct {
+	service *service.EvidenceService
+}
+
+func NewEvidenceHandler(svc *service.EvidenceService) *EvidenceHandler {
+	return &EvidenceHandler{service: svc}
+}
+
+// evidenceItem reads the item from the :id path parameter, answering 404
+// itself when it is not a valid ID.
+func evidenceItem(c *gin.Context, resource models.ResourceType) (models.ResourceRef, bool) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Item not found",
+		})
+		return models.ResourceRef{}, false
+	}
+	return models.ResourceRef{Type: resource, ID: id}, true
+}
+
+// respondEvidenceError maps the errors of the evidence service to responses.
+func respondEvidenceError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, service.ErrItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error":   "Item not found",
+		})
+	case errors.Is(err, service.ErrEvidenceLimit):
+		c.JSON(http.StatusConflict, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+	case strings.HasPrefix(err.Error(), "validation failed"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Failed to " + action + ": " + err.Error(),
+		})
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to " + action,
+		})
+	}
+}
+
+// List handles GET /api/v1/:resource/:id/evidence
+func (h *EvidenceHandler) List(resource models.ResourceType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ref, ok := evidenceItem(c, resource)
+		if !ok {
+			return
+		}
+
+		evidence, err := h.service.List(c.Request.Context(), ref)
+		if err != nil {
+			respondEvidenceError(c, err, "fetch evidence")
+			return
+		}
+
+		data := make([]map[string]interface{}, len(evidence))
+		for i := range evidence {
+			data[i] = evidence[i].ToResponse()
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    data,
+			"total":   len(data),
+		})
+	}
+}
+
+// Add handles POST /api/v1/:resource/:id/evidence
+func (h *EvidenceHandler) Add(resource models.ResourceType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ref, ok := evidenceItem(c, resource)
+		if !ok {
+			return
+		}
+
+		var evidence models.Evidence
+		if err := c.ShouldBindJSON(&evidence); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid request body: " + err.Error(),
+			})
+			return
+		}
+
+		if err := h.service.Add(c.Request.Context(), ref, &evidence); err != nil {
+			respondEvidenceError(c, err, "add evidence")
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"success": true,
+			"message": "Evidence added successfully",
+			"data":    evidence.ToResponse(),
+		})
+	}
+}
+
+type selectEvidenceRequest struct {
+	Candidates []models.Evidence `json:"candidates" binding:"required,min=1,max=1000"`
+	Limit      int               `json:"limit"`
+}
+
+// Select handles POST /api/v1/:resource/:id/evidence/select. The body holds
+// candidate mentions, typically those the item was generated from; the most
+// representative ones replace the item's previous automatic selection.
+func (h *EvidenceHandler) Select(resource models.ResourceType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ref, ok := evidenceItem(c, resource)
+		if !ok {
+			return
+		}
+
+		var req selectEvidenceRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid request body: " + err.Error(),
+			})
+			return
+		}
+		if req.Limit <= 0 {
+			req.Limit = 5
+		}
+
+		selected, err := h.service.Select(c.Request.Context(), ref, req.Candidates, req.Limit)
+		if err != nil {
+			respondEvidenceError(c, err, "select evidence")
+			return
+		}
+
+		data := make([]map[string]interface{}, len(selected))
+		for i := range selected {
+			data[i] = selected[i].ToResponse()
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "Evidence selected successfully",
+			"data":    data,
+			"total":   len(data),
+		})
+	}
+}
+
+// Remove handles DELETE /api/v1/:resource/:id/evidence/:evidence_id
+func (h *EvidenceHandler) Remove(resource models.ResourceType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ref, ok := evidenceItem(c, resource)
+		if !ok {
+			return
+		}
+
+		if err := h.service.Remove(c.Request.Context(), ref, c.Param("evidence_id")); err != nil {
+			if err.Error() == "evidence not found" {
+				c.JSON(http.StatusNotFound, gin.H{
+					"success": false,
+					"error":   "Evidence not found",
+				})
+				return
+			}
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to remove evidence",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "Evidence removed successfully",
+		})
+	}
+}
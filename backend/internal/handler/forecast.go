@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/forecast"
+	"naradai-backend/internal/service"
+)
+
+// forecastQuery reads ?days=14&season=7&level=0.95 for the forecast endpoints
+// and answers 400 itself when a value is out of range.
+func forecastQuery(c *gin.Context) (int, forecast.Options, bool) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "14"))
+	if err != nil || days < 1 || days > service.MaxForecastDays {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "days must be between 1 and " + strconv.Itoa(service.MaxForecastDays),
+		})
+		return 0, forecast.Options{}, false
+	}
+
+	season, err := strconv.Atoi(c.DefaultQuery("season", "0"))
+	if err != nil || season < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "season must be a non-negative number of points",
+		})
+		return 0, forecast.Options{}, false
+	}
+
+	level, err := strconv.ParseFloat(c.DefaultQuery("level", "0.95"), 64)
+	if err != nil || level <= 0 || level >= 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "level must be between 0 and 1",
+		})
+		return 0, forecast.Options{}, false
+	}
+
+	return days, forecast.Options{Season: season, Level: level}, true
+}
+
+// respondTooShort answers 422 for a series with too few points to forecast.
+func respondTooShort(c *gin.Context) {
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"success": false,
+		"error":   "At least " + strconv.Itoa(forecast.MinPoints) + " data points are needed for a forecast",
+	})
+}
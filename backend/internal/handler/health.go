@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/health"
+)
+
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Live handles GET /livez. It only reports that the process is serving
+// requests; dependencies are left to readiness so a database outage does
+// not get the server restarted.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready handles GET /readyz, answering 503 when a component check fails or
+// the server is shutting down.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Ready(c.Request.Context())
+
+	components := make([]map[string]interface{}, len(report.Components))
+	for i, status := range report.Components {
+		components[i] = status.ToResponse()
+	}
+
+	code, status := http.StatusOK, "ready"
+	if report.ShuttingDown {
+		code, status = http.StatusServiceUnavailable, "shutting_down"
+	} else if !report.Ready {
+		code, status = http.StatusServiceUnavailable, "not_ready"
+	}
+	c.JSON(code, gin.H{
+		"status":     status,
+		"components": components,
+	})
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestPriorityActionStatus(t *testing.T) {
+	api := newTestAPI(t)
+	action := api.create("/priority-actions", resourceCases[0].item(1))
+	if action["status"] != "not-started" {
+		t.Fatalf("new action status = %v, want not-started", action["status"])
+	}
+	path := "/priority-actions/" + action["id"].(string) + "/status"
+
+	expect(t, api.do(http.MethodPut, path, map[string]string{"status": "done"}), http.StatusBadRequest)
+	expect(t, api.do(http.MethodPut, path, map[string]string{"status": "completed"}, "If-Match", `"stale-5"`), http.StatusPreconditionFailed)
+
+	rec := api.do(http.MethodPut, path, map[string]string{"status": "in-progress"}, "If-Match", etagOf(action))
+	updated := expect(t, rec, http.StatusOK).object(t)
+	if updated["status"] != "in-progress" || updated["version"] != 2.0 {
+		t.Fatalf("after status update status = %v, version = %v", updated["status"], updated["version"])
+	}
+	if got := rec.Header().Get("ETag"); got != etagOf(updated) {
+		t.Fatalf("ETag = %s, want %s", got, etagOf(updated))
+	}
+	expect(t, api.do(http.MethodPut, "/priority-actions/65f1a0000000000000000000/status", map[string]string{"status": "completed"}), http.StatusNotFound)
+}
+
+func TestPriorityActionFilters(t *testing.T) {
+	api := newTestAPI(t)
+	critical := api.create("/priority-actions", resourceCases[0].item(1))
+	high := resourceCases[0].item(2)
+	high["priority"] = "high"
+	high["status"] = "completed"
+	completed := api.create("/priority-actions", high)
+
+	for query, want := range map[string][]string{
+		"?priority=critical":                {critical["id"].(string)},
+		"?priority=high&status=completed":   {completed["id"].(string)},
+		"?status=not-started":               {critical["id"].(string)},
+		"?priority=critical&status=invalid": {},
+	} {
+		body := expect(t, api.do(http.MethodGet, "/priority-actions"+query, nil), http.StatusOK)
+		listed := ids(body.list(t))
+		if len(listed) != len(want) || int(body.Total) != len(want) || (len(want) == 1 && listed[0] != want[0]) {
+			t.Errorf("%s listed %v (total %d), want %v", query, listed, body.Total, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+	"naradai-backend/internal/service"
+)
+
+type RelationHandler struct {
+	service *service.RelationService
+}
+
+func NewRelationHandler(svc *service.RelationService) *RelationHandler {
+	return &RelationHandler{service: svc}
+}
+
+// GetAll handles GET /api/v1/relations?resource=risks&id=...&type=drives.
+// resource and id together list the relations of one item.
+func (h *RelationHandler) GetAll(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
+	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+
+	filter := bson.M{}
+	if resource, id := c.Query("resource"), c.Query("id"); resource != "" && id != "" {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid id",
+			})
+			return
+		}
+		filter = repository.ItemFilter(models.ResourceRef{Type: models.ResourceType(resource), ID: objectID})
+	}
+	if relationType := c.Query("type"); relationType != "" {
+		filter["type"] = relationType
+	}
+
+	relations, total, err := h.service.GetAll(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch relations",
+		})
+		return
+	}
+
+	data := make([]map[string]interface{}, len(relations))
+	for i, relation := range relations {
+		data[i] = relation.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   total,
+	})
+}
+
+func (h *RelationHandler) GetByID(c *gin.Context) {
+	relation, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Relation not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch relation",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    relation.ToResponse(),
+	})
+}
+
+// Create handles POST /api/v1/relations, linking two items:
+// {"from": {"type": "conversation_clusters", "id": "..."}, "to": {"type": "risks", "id": "..."}, "type": "drives"}
+func (h *RelationHandler) Create(c *gin.Context) {
+	var relation models.Relation
+	if err := c.ShouldBindJSON(&relation); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), &relation); err != nil {
+		switch {
+		case errors.Is(err, service.ErrItemNotFound):
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Failed to create relation: " + err.Error(),
+			})
+		case errors.Is(err, service.ErrRelationExists):
+			c.JSON(http.StatusConflict, gin.H{
+				"success": false,
+				"error":   "These items are already linked with this relation type",
+			})
+		case strings.HasPrefix(err.Error(), "validation failed"):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Failed to create relation: " + err.Error(),
+			})
+		default:
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to create relation",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Relation created successfully",
+		"data":    relation.ToResponse(),
+	})
+}
+
+// Delete handles DELETE /api/v1/relations/:id, unlinking the two items.
+func (h *RelationHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err.Error() == "relation not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Relation not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to delete relation",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Relation deleted successfully",
+	})
+}
@@ -0,0 +1,149 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRelations(t *testing.T) {
+	api := newTestAPI(t)
+	cluster := api.create("/conversation-clusters", resourceCases[7].item(1))
+	risk := api.create("/risks", resourceCases[2].item(1))
+	action := api.create("/priority-actions", resourceCases[0].item(1))
+
+	link := func(fromType string, from map[string]interface{}, toType string, to map[string]interface{}, relationType string) map[string]interface{} {
+		return map[string]interface{}{
+			"from": map[string]interface{}{"type": fromType, "id": from["id"]},
+			"to":   map[string]interface{}{"type": toType, "id": to["id"]},
+			"type": relationType,
+		}
+	}
+	drives := api.create("/relations", link("conversation_clusters", cluster, "risks", risk, "drives"))
+	addresses := api.create("/relations", link("priority_actions", action, "risks", risk, "addresses"))
+
+	expect(t, api.do(http.MethodPost, "/relations", link("conversation_clusters", cluster, "risks", risk, "drives")), http.StatusConflict)
+	expect(t, api.do(http.MethodPost, "/relations", link("conversation_clusters", cluster, "risks", risk, "causes")), http.StatusBadRequest)
+	missing := map[string]interface{}{"id": "65f1a0000000000000000000"}
+	expect(t, api.do(http.MethodPost, "/relations", link("conversation_clusters", missing, "risks", risk, "drives")), http.StatusNotFound)
+
+	for query, want := range map[string]int64{
+		"":             2,
+		"?type=drives": 1,
+		"?resource=risks&id=" + risk["id"].(string):            2,
+		"?resource=priority_actions&id=" + risk["id"].(string): 0,
+	} {
+		if total := expect(t, api.do(http.MethodGet, "/relations"+query, nil), http.StatusOK).Total; total != want {
+			t.Errorf("%q listed %d relations, want %d", query, total, want)
+		}
+	}
+	expect(t, api.do(http.MethodGet, "/relations?resource=risks&id=bad", nil), http.StatusBadRequest)
+
+	expanded := expect(t, api.do(http.MethodGet, "/risks/"+risk["id"].(string)+"?expand=related", nil), http.StatusOK).object(t)
+	related, _ := expanded["related"].([]interface{})
+	if len(related) != 2 {
+		t.Fatalf("risk has %d related items, want 2", len(related))
+	}
+	for _, r := range related {
+		item := r.(map[string]interface{})
+		if item["direction"] != "incoming" || item["item"] == nil {
+			t.Errorf("related item %v", item)
+		}
+	}
+
+	// A delete that fails leaves the relations and the items depending on
+	// the item alone
+	clusterPath := "/conversation-clusters/" + cluster["id"].(string)
+	expect(t, api.do(http.MethodDelete, clusterPath, nil, "If-Match", `"stale-4"`), http.StatusPreconditionFailed)
+	expect(t, api.do(http.MethodGet, "/relations/"+drives["id"].(string), nil), http.StatusOK)
+	if total := expect(t, api.do(http.MethodGet, "/risks?flagged=true", nil), http.StatusOK).Total; total != 0 {
+		t.Fatalf("%d risks flagged before any delete", total)
+	}
+
+	// Deleting the cluster flags the risk that depended on it; deleting the
+	// risk flags the action that addressed it. Both relations are removed
+	expect(t, api.do(http.MethodDelete, clusterPath, nil), http.StatusOK)
+	expect(t, api.do(http.MethodGet, "/relations/"+drives["id"].(string), nil), http.StatusNotFound)
+	flagged := expect(t, api.do(http.MethodGet, "/risks?flagged=true", nil), http.StatusOK)
+	if items := flagged.list(t); flagged.Total != 1 || items[0]["id"] != risk["id"] || items[0]["flagged_reason"] == "" || items[0]["flagged_at"] == nil {
+		t.Fatalf("flagged risks %v", items)
+	}
+	if total := expect(t, api.do(http.MethodGet, "/risks?flagged=false", nil), http.StatusOK).Total; total != 0 {
+		t.Fatalf("%d risks not flagged, want 0", total)
+	}
+
+	expect(t, api.do(http.MethodDelete, "/risks/"+risk["id"].(string), nil), http.StatusOK)
+	expect(t, api.do(http.MethodGet, "/relations/"+addresses["id"].(string), nil), http.StatusNotFound)
+	actionPath := "/priority-actions/" + action["id"].(string)
+	action = expect(t, api.do(http.MethodGet, actionPath, nil), http.StatusOK).object(t)
+	if action["flagged_reason"] == "" {
+		t.Fatalf("action after deleting the risk it addressed %v", action)
+	}
+
+	// Replacing a flagged item keeps its flag; linking it to a new item to
+	// depend on clears it
+	replaced := resourceCases[0].item(1)
+	replaced["flagged_reason"] = ""
+	action = expect(t, api.do(http.MethodPut, actionPath, replaced), http.StatusOK).object(t)
+	if action["flagged_reason"] == "" {
+		t.Fatalf("flag after replacing the action %v", action)
+	}
+	other := api.create("/risks", resourceCases[2].item(2))
+	relinked := api.create("/relations", link("priority_actions", action, "risks", other, "addresses"))
+	action = expect(t, api.do(http.MethodGet, actionPath, nil), http.StatusOK).object(t)
+	if action["flagged_reason"] != "" || action["flagged_at"] != nil {
+		t.Fatalf("action linked to a new risk %v", action)
+	}
+
+	expect(t, api.do(http.MethodDelete, "/relations/"+relinked["id"].(string), nil), http.StatusOK)
+	expect(t, api.do(http.MethodDelete, "/relations/"+relinked["id"].(string), nil), http.StatusNotFound)
+}
+
+func TestEvidence(t *testing.T) {
+	api := newTestAPI(t)
+	topic := api.create("/discussion-topics", resourceCases[5].item(1))
+	path := "/discussion-topics/" + topic["id"].(string) + "/evidence"
+
+	listed := expect(t, api.do(http.MethodGet, path, nil), http.StatusOK)
+	if listed.Total != 0 || string(listed.Data) != "[]" {
+		t.Fatalf("new topic evidence total = %d, data = %s", listed.Total, listed.Data)
+	}
+
+	expect(t, api.do(http.MethodPost, path, map[string]interface{}{"text": "No source"}), http.StatusBadRequest)
+	expect(t, api.do(http.MethodPost, "/discussion-topics/65f1a0000000000000000000/evidence", map[string]interface{}{
+		"text": "Harga naik lagi", "source": "twitter",
+	}), http.StatusNotFound)
+	manual := api.create(path, map[string]interface{}{
+		"text":      "Promo minggu ini tidak berlaku di cabang saya.",
+		"source":    "twitter",
+		"sentiment": -0.4,
+	})
+	if manual["auto_selected"] != false {
+		t.Fatalf("hand-picked evidence auto_selected = %v", manual["auto_selected"])
+	}
+
+	candidates := []map[string]interface{}{
+		{"text": "Harga & Promo di aplikasi selalu lebih mahal dari di kasir, kecewa.", "source": "twitter", "sentiment": -0.5},
+		{"text": "Harga & Promo bundling kemarin lumayan hemat untuk keluarga.", "source": "instagram", "sentiment": 0.3},
+		{"text": "ok", "source": "news", "sentiment": 0.9},
+	}
+	rec := api.do(http.MethodPost, path+"/select", map[string]interface{}{"candidates": candidates, "limit": 2})
+	selected := expect(t, rec, http.StatusOK)
+	if selected.Total != 2 {
+		t.Fatalf("selected %d candidates, want 2", selected.Total)
+	}
+	for _, evidence := range selected.list(t) {
+		if evidence["auto_selected"] != true {
+			t.Errorf("selected evidence auto_selected = %v", evidence["auto_selected"])
+		}
+	}
+
+	// A new selection replaces the previous one and keeps hand-picked evidence
+	expect(t, api.do(http.MethodPost, path+"/select", map[string]interface{}{"candidates": candidates[:1]}), http.StatusOK)
+	if total := expect(t, api.do(http.MethodGet, path, nil), http.StatusOK).Total; total != 2 {
+		t.Fatalf("evidence after reselecting = %d, want 2", total)
+	}
+
+	expect(t, api.do(http.MethodDelete, path+"/"+manual["id"].(string), nil), http.StatusOK)
+	expect(t, api.do(http.MethodDelete, path+"/"+manual["id"].(string), nil), http.StatusNotFound)
+	expect(t, api.do(http.MethodDelete, path+"/not-an-id", nil), http.StatusNotFound)
+}
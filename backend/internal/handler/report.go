@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/report"
+	"naradai-backend/internal/service"
+)
+
+type ReportHandler struct {
+	dashboard *service.DashboardService
+	brand     report.Brand
+}
+
+func NewReportHandler(dashboard *service.DashboardService, brand report.Brand) *ReportHandler {
+	return &ReportHandler{dashboard: dashboard, brand: brand}
+}
+
+// Executive handles GET /api/v1/reports/executive.pdf?period=2024-05
+func (h *ReportHandler) Executive(c *gin.Context) {
+	period, err := report.ParsePeriod(c.Query("period"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	dashboard, err := h.dashboard.Load(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to load dashboard",
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := report.Render(&buf, report.NewExecutive(dashboard, period, nil), h.brand); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to render report",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("executive-report-%s.pdf", period.Start.Format("2006-01-02"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
@@ -0,0 +1,316 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/report"
+	"naradai-backend/internal/resource"
+	"naradai-backend/internal/service"
+)
+
+// ReportRunner starts report runs outside their schedule; the background
+// scheduler implements it.
+type ReportRunner interface {
+	RunNow(ctx context.Context, id string) (*models.ReportRun, error)
+}
+
+type ReportScheduleHandler struct {
+	service   *service.ReportScheduleService
+	scheduler ReportRunner
+}
+
+func NewReportScheduleHandler(svc *service.ReportScheduleService, scheduler ReportRunner) *ReportScheduleHandler {
+	return &ReportScheduleHandler{service: svc, scheduler: scheduler}
+}
+
+func (h *ReportScheduleHandler) GetAll(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
+	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+	isActive := c.Query("is_active")
+
+	filter := bson.M{}
+	if isActive == "true" {
+		filter["is_active"] = true
+	} else if isActive == "false" {
+		filter["is_active"] = false
+	}
+
+	schedules, total, err := h.service.GetAll(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch report schedules",
+		})
+		return
+	}
+
+	data := make([]map[string]interface{}, len(schedules))
+	for i, schedule := range schedules {
+		data[i] = schedule.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   total,
+	})
+}
+
+func (h *ReportScheduleHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	schedule, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Report schedule not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch report schedule",
+		})
+		return
+	}
+
+	resource.SetETag(c, schedule.ID, schedule.Version)
+	if resource.NotModified(c) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    schedule.ToResponse(),
+	})
+}
+
+func (h *ReportScheduleHandler) Create(c *gin.Context) {
+	var schedule models.ReportSchedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if _, err := report.ParsePeriod(schedule.Period, time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Failed to create report schedule: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), &schedule); err != nil {
+		if isScheduleValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Failed to create report schedule: " + err.Error(),
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to create report schedule",
+		})
+		return
+	}
+
+	resource.SetETag(c, schedule.ID, schedule.Version)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Report schedule created successfully",
+		"data":    schedule.ToResponse(),
+	})
+}
+
+func (h *ReportScheduleHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	version, ok := resource.IfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var schedule models.ReportSchedule
+	if err := c.ShouldBindJSON(&schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if _, err := report.ParsePeriod(schedule.Period, time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Failed to update report schedule: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Update(c.Request.Context(), id, &schedule, version); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			resource.RespondVersionConflict(c)
+			return
+		}
+		if errors.Is(err, resource.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Report schedule not found",
+			})
+			return
+		}
+		if isScheduleValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Failed to update report schedule: " + err.Error(),
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to update report schedule",
+		})
+		return
+	}
+
+	updatedSchedule, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Report schedule not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to update report schedule",
+		})
+		return
+	}
+	resource.SetETag(c, updatedSchedule.ID, updatedSchedule.Version)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Report schedule updated successfully",
+		"data":    updatedSchedule.ToResponse(),
+	})
+}
+
+func (h *ReportScheduleHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+	version, ok := resource.IfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id, version); err != nil {
+		if errors.Is(err, service.ErrVersionConflict) {
+			resource.RespondVersionConflict(c)
+			return
+		}
+		if errors.Is(err, resource.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Report schedule not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to delete report schedule",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Report schedule deleted successfully",
+	})
+}
+
+// Runs handles GET /api/v1/report-schedules/:id/runs
+func (h *ReportScheduleHandler) Runs(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "50"), 10, 64)
+	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+
+	runs, total, err := h.service.Runs(c.Request.Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Report schedule not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch report runs",
+		})
+		return
+	}
+
+	data := make([]map[string]interface{}, len(runs))
+	for i, run := range runs {
+		data[i] = run.ToResponse()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   total,
+	})
+}
+
+// RunNow handles POST /api/v1/report-schedules/:id/run. The report is sent in
+// the background; poll the runs endpoint for the outcome.
+func (h *ReportScheduleHandler) RunNow(c *gin.Context) {
+	run, err := h.scheduler.RunNow(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Report schedule not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to start report run",
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Report run started",
+		"data":    run.ToResponse(),
+	})
+}
+
+func isScheduleValidationError(err error) bool {
+	var validationErrs validator.ValidationErrors
+	return errors.As(err, &validationErrs) || errors.Is(err, service.ErrInvalidSchedule)
+}
@@ -0,0 +1,93 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestReportSchedules(t *testing.T) {
+	api := newTestAPI(t)
+	weekly := map[string]interface{}{
+		"name":       "Weekly summary",
+		"cron":       "0 8 * * 1",
+		"timezone":   "Asia/Jakarta",
+		"recipients": []string{"marketing@example.com"},
+		"format":     "pdf",
+		"period":     "7d",
+		"is_active":  true,
+	}
+
+	for field, value := range map[string]interface{}{
+		"cron":       "every monday",
+		"timezone":   "Mars/Olympus",
+		"recipients": []string{"not-an-email"},
+		"format":     "docx",
+		"period":     "fortnight",
+	} {
+		invalid := copyMap(weekly)
+		invalid[field] = value
+		expect(t, api.do(http.MethodPost, "/report-schedules", invalid), http.StatusBadRequest)
+	}
+
+	schedule := api.create("/report-schedules", weekly)
+	if schedule["next_run_at"] == nil || schedule["version"] != 1.0 {
+		t.Fatalf("new schedule %v", schedule)
+	}
+	path := "/report-schedules/" + schedule["id"].(string)
+
+	monthly := copyMap(weekly)
+	monthly["name"] = "Monthly summary"
+	monthly["cron"] = "0 8 1 * *"
+	expect(t, api.do(http.MethodPut, path, monthly, "If-Match", `"stale-4"`), http.StatusPreconditionFailed)
+	updated := expect(t, api.do(http.MethodPut, path, monthly, "If-Match", etagOf(schedule)), http.StatusOK).object(t)
+	if updated["name"] != "Monthly summary" || updated["version"] != 2.0 {
+		t.Fatalf("updated schedule %v", updated)
+	}
+
+	listed := expect(t, api.do(http.MethodGet, "/report-schedules", nil), http.StatusOK)
+	if listed.Total != 1 {
+		t.Fatalf("schedules listed = %d, want 1", listed.Total)
+	}
+
+	run := expect(t, api.do(http.MethodPost, path+"/run", nil), http.StatusAccepted).object(t)
+	if run["trigger"] != "manual" || run["status"] != "running" {
+		t.Fatalf("manual run %v", run)
+	}
+	runs := expect(t, api.do(http.MethodGet, path+"/runs", nil), http.StatusOK)
+	if items := runs.list(t); runs.Total != 1 || items[0]["id"] != run["id"] {
+		t.Fatalf("runs %v (total %d)", ids(items), runs.Total)
+	}
+	expect(t, api.do(http.MethodPost, "/report-schedules/65f1a0000000000000000000/run", nil), http.StatusNotFound)
+
+	expect(t, api.do(http.MethodDelete, path, nil), http.StatusOK)
+	expect(t, api.do(http.MethodGet, path, nil), http.StatusNotFound)
+	expect(t, api.do(http.MethodPut, path, monthly), http.StatusNotFound)
+	expect(t, api.do(http.MethodDelete, path, nil), http.StatusNotFound)
+
+	// Leaving out is_active creates an active schedule and keeps the stored value on update
+	implicit := copyMap(weekly)
+	delete(implicit, "is_active")
+	schedule = api.create("/report-schedules", implicit)
+	if schedule["is_active"] != true || schedule["next_run_at"] == nil {
+		t.Fatalf("schedule created without is_active %v", schedule)
+	}
+	path = "/report-schedules/" + schedule["id"].(string)
+	paused := copyMap(implicit)
+	paused["is_active"] = false
+	schedule = expect(t, api.do(http.MethodPut, path, paused), http.StatusOK).object(t)
+	if schedule["is_active"] != false || schedule["next_run_at"] != nil {
+		t.Fatalf("paused schedule %v", schedule)
+	}
+	schedule = expect(t, api.do(http.MethodPut, path, implicit), http.StatusOK).object(t)
+	if schedule["is_active"] != false {
+		t.Fatalf("schedule updated without is_active %v", schedule)
+	}
+}
+
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,524 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// resourceCase is one of the dashboard resources sharing the CRUD, bulk,
+// export, import and reorder endpoints.
+type resourceCase struct {
+	path string
+	// item builds a valid item; n makes its natural key unique
+	item func(n int) map[string]interface{}
+	// field is changed by the update and patch tests, to a value of the same type
+	field      string
+	value      interface{}
+	naturalKey string
+	// active resources have an is_active flag
+	active bool
+}
+
+var resourceCases = []resourceCase{
+	{
+		path: "/priority-actions",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"priority":       "critical",
+				"title":          fmt.Sprintf("Address delivery complaints %d", n),
+				"description":    "Mentions about late deliveries spiked this week.",
+				"impact":         "High",
+				"effort":         "Medium",
+				"recommendation": "Publish revised delivery estimates today.",
+				"mentions":       1240,
+				"sentiment":      -0.62,
+				"trend":          "increasing",
+				"icon":           "Package",
+			}
+		},
+		field:      "mentions",
+		value:      1500.0,
+		naturalKey: "title",
+	},
+	{
+		path: "/dashboard-stats",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"label":  fmt.Sprintf("Total Mentions %d", n),
+				"value":  "124.5K",
+				"change": "+12.4%",
+				"trend":  "up",
+				"icon":   "BarChart3",
+				"unit":   "number",
+			}
+		},
+		field:      "value",
+		value:      "130K",
+		naturalKey: "label",
+		active:     true,
+	},
+	{
+		path: "/risks",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"title":             fmt.Sprintf("Viral complaint thread %d", n),
+				"description":       "A complaint about delivery delays is gaining traction.",
+				"severity":          "high",
+				"probability":       65,
+				"impact_assessment": "Brand trust drops among first-time customers.",
+				"trend":             "increasing",
+			}
+		},
+		field:      "probability",
+		value:      80.0,
+		naturalKey: "title",
+		active:     true,
+	},
+	{
+		path: "/opportunities",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"title":            fmt.Sprintf("Ride the seasonal menu buzz %d", n),
+				"description":      "Organic excitement about the seasonal menu is growing.",
+				"potential":        "high",
+				"confidence_score": 78,
+				"timeframe":        "Short-term",
+				"category":         "Product",
+				"trend":            "increasing",
+			}
+		},
+		field:      "confidence_score",
+		value:      90.0,
+		naturalKey: "title",
+		active:     true,
+	},
+	{
+		path: "/sentiment-trends",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"title":            fmt.Sprintf("Sentiment Over Time %d", n),
+				"period":           "Last 7 days",
+				"positive_percent": 62.5,
+				"negative_percent": 18.3,
+				"neutral_percent":  19.2,
+				"trend_data": []map[string]interface{}{
+					{"date": "2024-05-01", "positive": 58.1, "negative": 20.4},
+					{"date": "2024-05-02", "positive": 59.7, "negative": 19.8},
+				},
+			}
+		},
+		field:      "positive_percent",
+		value:      64.0,
+		naturalKey: "title",
+		active:     true,
+	},
+	{
+		path: "/discussion-topics",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"name":            fmt.Sprintf("Harga & Promo %d", n),
+				"volume":          8420,
+				"sentiment_score": -0.35,
+				"color":           "from-orange-500 to-amber-500",
+			}
+		},
+		field:      "volume",
+		value:      9000.0,
+		naturalKey: "name",
+		active:     true,
+	},
+	{
+		path: "/competitive-analyses",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"name":           fmt.Sprintf("Senja Coffee %d", n),
+				"share_of_voice": 34.5,
+				"sentiment":      71.2,
+				"engagement":     4.8,
+				"position":       "#1 in Share of Voice",
+				"gap_to_leader":  "Leader",
+			}
+		},
+		field:      "share_of_voice",
+		value:      36.0,
+		naturalKey: "name",
+		active:     true,
+	},
+	{
+		path: "/conversation-clusters",
+		item: func(n int) map[string]interface{} {
+			return map[string]interface{}{
+				"theme":     fmt.Sprintf("Keluhan antrean %d", n),
+				"size":      2310,
+				"sentiment": -0.48,
+				"trend":     "up",
+				"keywords":  []string{"antrean", "kasir"},
+			}
+		},
+		field:      "size",
+		value:      2500.0,
+		naturalKey: "theme",
+		active:     true,
+	},
+}
+
+func TestResourceCRUD(t *testing.T) {
+	for _, rc := range resourceCases {
+		t.Run(strings.TrimPrefix(rc.path, "/"), func(t *testing.T) {
+			api := newTestAPI(t)
+
+			rec := api.do(http.MethodPost, rc.path, rc.item(1))
+			created := expect(t, rec, http.StatusCreated).object(t)
+			if created["version"] != 1.0 {
+				t.Fatalf("version = %v, want 1", created["version"])
+			}
+			if got := rec.Header().Get("ETag"); got != etagOf(created) {
+				t.Fatalf("ETag = %s, want %s", got, etagOf(created))
+			}
+			id := created["id"].(string)
+
+			invalid := rc.item(2)
+			delete(invalid, rc.naturalKey)
+			expect(t, api.do(http.MethodPost, rc.path, invalid), http.StatusBadRequest)
+
+			rec = api.do(http.MethodGet, rc.path+"/"+id, nil)
+			if got := expect(t, rec, http.StatusOK).object(t); got["id"] != id {
+				t.Fatalf("GetByID returned %v", got["id"])
+			}
+			expect(t, api.do(http.MethodGet, rc.path+"/"+id, nil, "If-None-Match", etagOf(created)), http.StatusNotModified)
+			listETag := api.do(http.MethodGet, rc.path, nil).Header().Get("ETag")
+			if !strings.HasPrefix(listETag, `W/"`) {
+				t.Fatalf("list ETag = %q, want a weak tag", listETag)
+			}
+			expect(t, api.do(http.MethodGet, rc.path, nil, "If-None-Match", listETag), http.StatusNotModified)
+			expect(t, api.do(http.MethodGet, rc.path+"?limit=1", nil, "If-None-Match", listETag), http.StatusOK)
+			expect(t, api.do(http.MethodGet, rc.path+"/65f1a0000000000000000000", nil), http.StatusNotFound)
+
+			// Update with a stale version is refused, with the current one applied
+			update := rc.item(1)
+			update[rc.field] = rc.value
+			expect(t, api.do(http.MethodPut, rc.path+"/"+id, update, "If-Match", fmt.Sprintf(`"%s-7"`, id)), http.StatusPreconditionFailed)
+			updated := expect(t, api.do(http.MethodPut, rc.path+"/"+id, update, "If-Match", etagOf(created)), http.StatusOK).object(t)
+			if updated["version"] != 2.0 || updated[rc.field] != rc.value {
+				t.Fatalf("after update version = %v, %s = %v", updated["version"], rc.field, updated[rc.field])
+			}
+			if updated["created_at"] != created["created_at"] {
+				t.Fatalf("update changed created_at from %v to %v", created["created_at"], updated["created_at"])
+			}
+			expect(t, api.do(http.MethodGet, rc.path, nil, "If-None-Match", listETag), http.StatusOK)
+
+			patch := fmt.Sprintf(`{%q: %s}`, rc.naturalKey, mustJSON(t, "Patched "+rc.naturalKey))
+			rec = api.do(http.MethodPatch, rc.path+"/"+id, patch, "Content-Type", "application/merge-patch+json", "If-Match", etagOf(updated))
+			patched := expect(t, rec, http.StatusOK).object(t)
+			if patched["version"] != 3.0 || patched[rc.naturalKey] != "Patched "+rc.naturalKey || patched[rc.field] != rc.value {
+				t.Fatalf("after patch %v", patched)
+			}
+			rec = api.do(http.MethodPatch, rc.path+"/"+id, `[{"op": "replace", "path": "/`+rc.naturalKey+`", "value": "JSON patched"}]`, "Content-Type", "application/json-patch+json")
+			if got := expect(t, rec, http.StatusOK).object(t); got[rc.naturalKey] != "JSON patched" {
+				t.Fatalf("after JSON patch %s = %v", rc.naturalKey, got[rc.naturalKey])
+			}
+			expect(t, api.do(http.MethodPatch, rc.path+"/"+id, "x", "Content-Type", "text/plain"), http.StatusUnsupportedMediaType)
+
+			expect(t, api.do(http.MethodDelete, rc.path+"/"+id, nil, "If-Match", etagOf(created)), http.StatusPreconditionFailed)
+			expect(t, api.do(http.MethodDelete, rc.path+"/"+id, nil), http.StatusOK)
+			expect(t, api.do(http.MethodGet, rc.path+"/"+id, nil), http.StatusNotFound)
+			expect(t, api.do(http.MethodDelete, rc.path+"/"+id, nil), http.StatusNotFound)
+			expect(t, api.do(http.MethodPut, rc.path+"/"+id, update), http.StatusNotFound)
+
+			expect(t, api.do(http.MethodGet, rc.path+"/not-an-id", nil), http.StatusBadRequest)
+			expect(t, api.do(http.MethodPut, rc.path+"/not-an-id", update), http.StatusBadRequest)
+			expect(t, api.do(http.MethodPatch, rc.path+"/not-an-id", patch, "Content-Type", "application/merge-patch+json"), http.StatusBadRequest)
+			expect(t, api.do(http.MethodDelete, rc.path+"/not-an-id", nil), http.StatusBadRequest)
+		})
+	}
+}
+
+func TestResourcePatchWithoutVersion(t *testing.T) {
+	api := newTestAPI(t)
+	item := resourceCases[2].item(1)
+	item["mitigation_strategy"] = []string{}
+	risk := api.create("/risks", item)
+	path := "/risks/" + risk["id"].(string)
+
+	// Concurrent patches without If-Match are each applied to the item as
+	// the others left it, or refused, but never lost
+	var wg sync.WaitGroup
+	var applied atomic.Int32
+	start := make(chan struct{})
+	for n := 0; n < 32; n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			patch := fmt.Sprintf(`[{"op": "add", "path": "/mitigation_strategy/-", "value": "Step %d"}]`, n)
+			rec := api.do(http.MethodPatch, path, patch, "Content-Type", "application/json-patch+json")
+			switch rec.Code {
+			case http.StatusOK:
+				applied.Add(1)
+			case http.StatusPreconditionFailed:
+			default:
+				t.Errorf("patch %d = %d: %s", n, rec.Code, rec.Body.String())
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	got := expect(t, api.do(http.MethodGet, path, nil), http.StatusOK).object(t)
+	steps, _ := got["mitigation_strategy"].([]interface{})
+	if applied.Load() == 0 || len(steps) != int(applied.Load()) || got["version"] != float64(1+applied.Load()) {
+		t.Fatalf("%d patches applied, item has %d steps at version %v", applied.Load(), len(steps), got["version"])
+	}
+}
+
+func TestResourceList(t *testing.T) {
+	for _, rc := range resourceCases {
+		t.Run(strings.TrimPrefix(rc.path, "/"), func(t *testing.T) {
+			api := newTestAPI(t)
+			for n := 1; n <= 3; n++ {
+				api.create(rc.path, rc.item(n))
+			}
+
+			body := expect(t, api.do(http.MethodGet, rc.path, nil), http.StatusOK)
+			if items := body.list(t); body.Total != 3 || len(items) != 3 {
+				t.Fatalf("total = %d with %d items, want 3", body.Total, len(items))
+			}
+			all := body.list(t)
+
+			body = expect(t, api.do(http.MethodGet, rc.path+"?limit=1&offset=1", nil), http.StatusOK)
+			page := body.list(t)
+			if body.Total != 3 || len(page) != 1 || page[0]["id"] != all[1]["id"] {
+				t.Fatalf("page total = %d, items = %v", body.Total, ids(page))
+			}
+			body = expect(t, api.do(http.MethodGet, rc.path+"?offset=5", nil), http.StatusOK)
+			if body.Total != 3 || len(body.list(t)) != 0 {
+				t.Fatalf("past the end total = %d, items = %s", body.Total, body.Data)
+			}
+		})
+	}
+}
+
+func TestResourceBulk(t *testing.T) {
+	for _, rc := range resourceCases {
+		t.Run(strings.TrimPrefix(rc.path, "/"), func(t *testing.T) {
+			api := newTestAPI(t)
+			existing := api.create(rc.path, rc.item(1))
+			update := rc.item(1)
+			update[rc.field] = rc.value
+			stale := int64(9)
+
+			rec := api.do(http.MethodPost, rc.path+"/bulk", map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "create", "data": rc.item(2)},
+					{"op": "update", "id": existing["id"], "data": update},
+					{"op": "delete", "id": existing["id"], "version": stale},
+					{"op": "delete", "id": "65f1a0000000000000000000"},
+					{"op": "create", "data": map[string]interface{}{}},
+				},
+			})
+			var body struct {
+				Success   bool `json:"success"`
+				Succeeded int  `json:"succeeded"`
+				Failed    int  `json:"failed"`
+				Data      []struct {
+					Status int                    `json:"status"`
+					Data   map[string]interface{} `json:"data"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(expectRaw(t, rec, http.StatusOK), &body); err != nil {
+				t.Fatal(err)
+			}
+			want := []int{http.StatusCreated, http.StatusOK, http.StatusPreconditionFailed, http.StatusNotFound, http.StatusBadRequest}
+			for i, result := range body.Data {
+				if result.Status != want[i] {
+					t.Errorf("operation %d status = %d, want %d", i, result.Status, want[i])
+				}
+			}
+			if body.Success || body.Succeeded != 2 || body.Failed != 3 {
+				t.Fatalf("success = %v, succeeded = %d, failed = %d", body.Success, body.Succeeded, body.Failed)
+			}
+			if got := body.Data[1].Data[rc.field]; got != rc.value {
+				t.Fatalf("bulk update %s = %v, want %v", rc.field, got, rc.value)
+			}
+
+			list := expect(t, api.do(http.MethodGet, rc.path, nil), http.StatusOK)
+			if list.Total != 2 {
+				t.Fatalf("total after bulk = %d, want 2", list.Total)
+			}
+		})
+	}
+}
+
+func TestResourceExportImport(t *testing.T) {
+	for _, rc := range resourceCases {
+		t.Run(strings.TrimPrefix(rc.path, "/"), func(t *testing.T) {
+			api := newTestAPI(t)
+			first := api.create(rc.path, rc.item(1))
+			api.create(rc.path, rc.item(2))
+
+			rec := api.do(http.MethodGet, rc.path+"/export?format=json", nil)
+			var exported []map[string]interface{}
+			if err := json.Unmarshal(expectRaw(t, rec, http.StatusOK), &exported); err != nil {
+				t.Fatal(err)
+			}
+			if len(exported) != 2 {
+				t.Fatalf("exported %d items, want 2", len(exported))
+			}
+			if !strings.Contains(rec.Header().Get("Content-Disposition"), "attachment") {
+				t.Fatalf("Content-Disposition = %q", rec.Header().Get("Content-Disposition"))
+			}
+
+			rec = api.do(http.MethodGet, rc.path+"/export", nil)
+			csv := string(expectRaw(t, rec, http.StatusOK))
+			if lines := strings.Count(strings.TrimSpace(csv), "\n"); lines != 2 {
+				t.Fatalf("CSV export has %d data rows, want 2:\n%s", lines, csv)
+			}
+			expect(t, api.do(http.MethodGet, rc.path+"/export?format=pdf", nil), http.StatusBadRequest)
+
+			// Re-importing the export with upsert updates the same items
+			rows := []map[string]interface{}{rc.item(1), rc.item(3)}
+			body := expect(t, api.do(http.MethodPost, rc.path+"/import?format=json&upsert=true&dry_run=true", rows), http.StatusOK)
+			report := body.object(t)
+			if report["created"] != 1.0 || report["updated"] != 1.0 {
+				t.Fatalf("dry run report %v", report)
+			}
+			if total := expect(t, api.do(http.MethodGet, rc.path, nil), http.StatusOK).Total; total != 2 {
+				t.Fatalf("dry run wrote items, total = %d", total)
+			}
+
+			invalid := rc.item(4)
+			delete(invalid, rc.naturalKey)
+			expect(t, api.do(http.MethodPost, rc.path+"/import?format=json", []map[string]interface{}{rc.item(4), invalid}), http.StatusUnprocessableEntity)
+
+			expect(t, api.do(http.MethodPost, rc.path+"/import?format=json&upsert=true", rows), http.StatusOK)
+			if total := expect(t, api.do(http.MethodGet, rc.path, nil), http.StatusOK).Total; total != 3 {
+				t.Fatalf("total after import = %d, want 3", total)
+			}
+
+			// An upsert row only changes the columns the file has
+			csv = fmt.Sprintf("%s,%s\n%v,%v\n", rc.naturalKey, rc.field, rc.item(1)[rc.naturalKey], rc.value)
+			body = expect(t, api.do(http.MethodPost, rc.path+"/import?format=csv&upsert=true", csv, "Content-Type", "text/csv"), http.StatusOK)
+			if report := body.object(t); report["updated"] != 1.0 {
+				t.Fatalf("partial upsert report %v", report)
+			}
+			updated := expect(t, api.do(http.MethodGet, rc.path+"/"+first["id"].(string), nil), http.StatusOK).object(t)
+			if updated[rc.field] != rc.value {
+				t.Errorf("%s = %v after upsert, want %v", rc.field, updated[rc.field], rc.value)
+			}
+			for field := range rc.item(1) {
+				if field != rc.field && !reflect.DeepEqual(updated[field], first[field]) {
+					t.Errorf("upsert changed %s from %v to %v", field, first[field], updated[field])
+				}
+			}
+			if rc.active && updated["is_active"] != true {
+				t.Errorf("upsert set is_active = %v", updated["is_active"])
+			}
+		})
+	}
+}
+
+func TestExportEscapesFormulas(t *testing.T) {
+	api := newTestAPI(t)
+	name := `=HYPERLINK("https://example.com","open")`
+	api.create("/discussion-topics", map[string]interface{}{"name": name, "volume": 10})
+
+	csv := string(expectRaw(t, api.do(http.MethodGet, "/discussion-topics/export", nil), http.StatusOK))
+	if !strings.Contains(csv, `"'=HYPERLINK(`) {
+		t.Fatalf("CSV export does not escape the formula:\n%s", csv)
+	}
+
+	// Importing the export restores the text as it was entered
+	expect(t, api.do(http.MethodPost, "/discussion-topics/import?format=csv", csv, "Content-Type", "text/csv"), http.StatusOK)
+	for _, topic := range expect(t, api.do(http.MethodGet, "/discussion-topics", nil), http.StatusOK).list(t) {
+		if topic["name"] != name {
+			t.Errorf("name = %q, want %q", topic["name"], name)
+		}
+	}
+}
+
+func TestResourceReorder(t *testing.T) {
+	for _, rc := range resourceCases {
+		t.Run(strings.TrimPrefix(rc.path, "/"), func(t *testing.T) {
+			api := newTestAPI(t)
+			a := api.create(rc.path, rc.item(1))
+			b := api.create(rc.path, rc.item(2))
+			c := api.create(rc.path, rc.item(3))
+
+			order := []string{a["id"].(string), c["id"].(string), b["id"].(string)}
+			expect(t, api.do(http.MethodPost, rc.path+"/reorder", map[string]interface{}{"ids": order}), http.StatusOK)
+			listed := expect(t, api.do(http.MethodGet, rc.path, nil), http.StatusOK).list(t)
+			if got := strings.Join(ids(listed), ","); got != strings.Join(order, ",") {
+				t.Fatalf("order = %s, want %s", got, strings.Join(order, ","))
+			}
+			if listed[0]["version"] != 2.0 {
+				t.Fatalf("reorder did not bump the version: %v", listed[0]["version"])
+			}
+
+			for _, invalid := range [][]string{
+				{order[0], order[0]},
+				{order[0], "not-an-id"},
+				{order[0], "65f1a0000000000000000000"},
+			} {
+				expect(t, api.do(http.MethodPost, rc.path+"/reorder", map[string]interface{}{"ids": invalid}), http.StatusBadRequest)
+			}
+			listed = expect(t, api.do(http.MethodGet, rc.path, nil), http.StatusOK).list(t)
+			if got := strings.Join(ids(listed), ","); got != strings.Join(order, ",") {
+				t.Fatalf("a rejected reorder changed the order to %s", got)
+			}
+		})
+	}
+}
+
+func TestResourceActiveFilter(t *testing.T) {
+	for _, rc := range resourceCases {
+		if !rc.active {
+			continue
+		}
+		t.Run(strings.TrimPrefix(rc.path, "/"), func(t *testing.T) {
+			api := newTestAPI(t)
+			active := api.create(rc.path, rc.item(1))
+			inactive := api.create(rc.path, rc.item(2))
+			if active["is_active"] != true {
+				t.Fatalf("new item is_active = %v, want true", active["is_active"])
+			}
+			rec := api.do(http.MethodPatch, rc.path+"/"+inactive["id"].(string), `{"is_active": false}`, "Content-Type", "application/merge-patch+json")
+			expect(t, rec, http.StatusOK)
+
+			for query, want := range map[string]string{
+				"?is_active=true":  active["id"].(string),
+				"?is_active=false": inactive["id"].(string),
+			} {
+				listed := expect(t, api.do(http.MethodGet, rc.path+query, nil), http.StatusOK).list(t)
+				if len(listed) != 1 || listed[0]["id"] != want {
+					t.Errorf("%s listed %v, want %s", query, ids(listed), want)
+				}
+			}
+		})
+	}
+}
+
+// expectRaw checks the response status and returns the raw body, for
+// responses that are not wrapped in an envelope.
+func expectRaw(t *testing.T, rec *httptest.ResponseRecorder, status int) []byte {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, status, rec.Body.String())
+	}
+	return rec.Body.Bytes()
+}
+
+func mustJSON(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/resource"
+	"naradai-backend/internal/service"
+)
+
+// The handlers of the resources with no routes beyond the shared ones.
+type (
+	DiscussionTopicHandler     = resource.Handler[models.DiscussionTopic, *models.DiscussionTopic]
+	CompetitiveAnalysisHandler = resource.Handler[models.CompetitiveAnalysis, *models.CompetitiveAnalysis]
+	ConversationClusterHandler = resource.Handler[models.ConversationCluster, *models.ConversationCluster]
+	RiskHandler                = resource.Handler[models.Risk, *models.Risk]
+	OpportunityHandler         = resource.Handler[models.Opportunity, *models.Opportunity]
+)
+
+func NewDiscussionTopicHandler(svc *service.DiscussionTopicService) *DiscussionTopicHandler {
+	return resource.NewHandler(svc)
+}
+
+func NewCompetitiveAnalysisHandler(svc *service.CompetitiveAnalysisService) *CompetitiveAnalysisHandler {
+	return resource.NewHandler(svc)
+}
+
+func NewConversationClusterHandler(svc *service.ConversationClusterService) *ConversationClusterHandler {
+	return resource.NewHandler(svc)
+}
+
+func NewRiskHandler(svc *service.RiskService) *RiskHandler {
+	return resource.NewHandler(svc)
+}
+
+func NewOpportunityHandler(svc *service.OpportunityService) *OpportunityHandler {
+	return resource.NewHandler(svc)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/models"
+)
+
+// Handlers are the handlers of the API routes.
+type Handlers struct {
+	PriorityActions      *PriorityActionHandler
+	DashboardStats       *DashboardStatHandler
+	Risks                *RiskHandler
+	Opportunities        *OpportunityHandler
+	SentimentTrends      *SentimentTrendHandler
+	DiscussionTopics     *DiscussionTopicHandler
+	CompetitiveAnalyses  *CompetitiveAnalysisHandler
+	ConversationClusters *ConversationClusterHandler
+	Dashboard            *DashboardHandler
+	Reports              *ReportHandler
+	Relations            *RelationHandler
+	Evidence             *EvidenceHandler
+	Anomalies            *AnomalyHandler
+	Admin                *AdminHandler
+	Snapshots            *SnapshotHandler
+	ReportSchedules      *ReportScheduleHandler
+}
+
+// Register adds the API routes to api, the /api/v1 group.
+func (h *Handlers) Register(api gin.IRouter) {
+	// Resource routes
+	h.PriorityActions.Register(api)
+	h.DashboardStats.Register(api)
+	h.Risks.Register(api)
+	h.Opportunities.Register(api)
+	h.SentimentTrends.Register(api)
+	h.DiscussionTopics.Register(api)
+	h.CompetitiveAnalyses.Register(api)
+	h.ConversationClusters.Register(api)
+
+	// Dashboard routes
+	api.GET("/dashboard/export", h.Dashboard.Export)
+
+	// Report routes
+	api.GET("/reports/executive.pdf", h.Reports.Executive)
+
+	// Relation routes
+	api.GET("/relations", h.Relations.GetAll)
+	api.GET("/relations/:id", h.Relations.GetByID)
+	api.POST("/relations", h.Relations.Create)
+	api.DELETE("/relations/:id", h.Relations.Delete)
+
+	// Evidence routes
+	for path, resource := range map[string]models.ResourceType{
+		"/conversation-clusters": models.ResourceConversationClusters,
+		"/discussion-topics":     models.ResourceDiscussionTopics,
+		"/risks":                 models.ResourceRisks,
+		"/opportunities":         models.ResourceOpportunities,
+		"/priority-actions":      models.ResourcePriorityActions,
+	} {
+		api.GET(path+"/:id/evidence", h.Evidence.List(resource))
+		api.POST(path+"/:id/evidence", h.Evidence.Add(resource))
+		api.POST(path+"/:id/evidence/select", h.Evidence.Select(resource))
+		api.DELETE(path+"/:id/evidence/:evidence_id", h.Evidence.Remove(resource))
+	}
+
+	// Anomaly routes
+	api.GET("/anomalies", h.Anomalies.GetAll)
+
+	// Admin routes
+	api.GET("/admin/config", h.Admin.Config)
+
+	// Snapshot routes
+	api.GET("/snapshots", h.Snapshots.GetAll)
+	api.GET("/snapshots/diff", h.Snapshots.Diff)
+	api.GET("/snapshots/:id", h.Snapshots.GetByID)
+	api.POST("/snapshots", h.Snapshots.Create)
+	api.DELETE("/snapshots/:id", h.Snapshots.Delete)
+
+	// Report Schedule routes
+	api.GET("/report-schedules", h.ReportSchedules.GetAll)
+	api.GET("/report-schedules/:id", h.ReportSchedules.GetByID)
+	api.GET("/report-schedules/:id/runs", h.ReportSchedules.Runs)
+	api.POST("/report-schedules", h.ReportSchedules.Create)
+	api.POST("/report-schedules/:id/run", h.ReportSchedules.RunNow)
+	api.PUT("/report-schedules/:id", h.ReportSchedules.Update)
+	api.DELETE("/report-schedules/:id", h.ReportSchedules.Delete)
+}
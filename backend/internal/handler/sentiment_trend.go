@@ -1,179 +1,90 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"naradai-backend/internal/models"
+	"naradai-backend/internal/resource"
 	"naradai-backend/internal/service"
 )
 
+// SentimentTrendHandler serves sentiment trends, whose data points carry
+// the anomalies found in them, and their forecasts.
 type SentimentTrendHandler struct {
+	*resource.Handler[models.SentimentTrend, *models.SentimentTrend]
 	service *service.SentimentTrendService
 }
 
 func NewSentimentTrendHandler(svc *service.SentimentTrendService) *SentimentTrendHandler {
-	return &SentimentTrendHandler{service: svc}
-}
-
-func (h *SentimentTrendHandler) GetAll(c *gin.Context) {
-	isActive := c.Query("is_active")
-	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
-	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
-
-	filter := bson.M{}
-	if isActive == "true" {
-		filter["is_active"] = true
-	} else if isActive == "false" {
-		filter["is_active"] = false
-	}
-
-	trends, total, err := h.service.GetAll(c.Request.Context(), filter, limit, offset)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to fetch sentiment trends",
-		})
-		return
+	h := &SentimentTrendHandler{
+		Handler: resource.NewHandler(svc.Service),
+		service: svc,
 	}
-
-	data := make([]map[string]interface{}, len(trends))
-	for i, trend := range trends {
-		data[i] = trend.ToResponse()
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    data,
-		"total":   total,
-	})
+	h.Present = annotatedTrendResponse
+	return h
 }
 
-func (h *SentimentTrendHandler) GetByID(c *gin.Context) {
-	id := c.Param("id")
-
-	trend, err := h.service.GetByID(c.Request.Context(), id)
-	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			c.JSON(http.StatusNotFound, gin.H{
-				"success": false,
-				"error":   "Sentiment trend not found",
-			})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to fetch sentiment trend",
-		})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    trend.ToResponse(),
-	})
+// Register adds the sentiment trend routes to api, the /api/v1 group.
+func (h *SentimentTrendHandler) Register(api gin.IRouter) {
+	h.Handler.Register(api)
+	api.GET("/sentiment-trends/:id/forecast", h.Forecast)
 }
 
-func (h *SentimentTrendHandler) Create(c *gin.Context) {
-	var trend models.SentimentTrend
-	if err := c.ShouldBindJSON(&trend); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Invalid request body: " + err.Error(),
-		})
-		return
-	}
-
-	if err := h.service.Validate(&trend); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Validation failed: " + err.Error(),
-		})
-		return
-	}
-
-	if err := h.service.Create(c.Request.Context(), &trend); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to create sentiment trend",
-		})
-		return
-	}
-
-	c.JSON(http.StatusCreated, gin.H{
-		"success": true,
-		"message": "Sentiment trend created successfully",
-		"data":    trend.ToResponse(),
-	})
+// annotatedTrendResponse is the trend's response with the anomalies found in
+// its positive and negative series attached to the data points.
+func annotatedTrendResponse(trend *models.SentimentTrend) map[string]interface{} {
+	response := trend.ToResponse()
+	response["trend_data"] = service.AnnotateTrend(trend)
+	return response
 }
 
-func (h *SentimentTrendHandler) Update(c *gin.Context) {
-	id := c.Param("id")
-
-	var trend models.SentimentTrend
-	if err := c.ShouldBindJSON(&trend); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Invalid request body: " + err.Error(),
-		})
-		return
-	}
-
-	if err := h.service.Validate(&trend); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"success": false,
-			"error":   "Validation failed: " + err.Error(),
-		})
+// Forecast handles GET /api/v1/sentiment-trends/:id/forecast?days=14&season=7&level=0.95
+func (h *SentimentTrendHandler) Forecast(c *gin.Context) {
+	days, opts, ok := forecastQuery(c)
+	if !ok {
 		return
 	}
 
-	if err := h.service.Update(c.Request.Context(), id, &trend); err != nil {
-		if err.Error() == "sentiment trend not found" {
-			c.JSON(http.StatusNotFound, gin.H{
+	result, err := h.service.Forecast(c.Request.Context(), c.Param("id"), days, opts)
+	if err != nil {
+		switch {
+		case errors.Is(err, primitive.ErrInvalidHex):
+			c.JSON(http.StatusBadRequest, gin.H{
 				"success": false,
-				"error":   "Sentiment trend not found",
+				"error":   "Invalid id",
 			})
 			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to update sentiment trend",
-		})
-		return
-	}
-
-	updatedTrend, _ := h.service.GetByID(c.Request.Context(), id)
-
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"message": "Sentiment trend updated successfully",
-		"data":    updatedTrend.ToResponse(),
-	})
-}
-
-func (h *SentimentTrendHandler) Delete(c *gin.Context) {
-	id := c.Param("id")
-
-	if err := h.service.Delete(c.Request.Context(), id); err != nil {
-		if err.Error() == "sentiment trend not found" {
+		case errors.Is(err, resource.ErrNotFound):
 			c.JSON(http.StatusNotFound, gin.H{
 				"success": false,
 				"error":   "Sentiment trend not found",
 			})
 			return
 		}
+		if errors.Is(err, service.ErrTooShort) {
+			respondTooShort(c)
+			return
+		}
+		c.Error(err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
-			"error":   "Failed to delete sentiment trend",
+			"error":   "Failed to forecast sentiment trend",
 		})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "Sentiment trend deleted successfully",
+		"data": gin.H{
+			"id":         result.Trend.ID.Hex(),
+			"title":      result.Trend.Title,
+			"method":     result.Method,
+			"level":      result.Level,
+			"trend_data": result.Trend.TrendData,
+			"forecast":   result.Forecast,
+		},
 	})
 }
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/service"
+)
+
+type SnapshotHandler struct {
+	service *service.SnapshotService
+}
+
+func NewSnapshotHandler(svc *service.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{service: svc}
+}
+
+// GetAll handles GET /api/v1/snapshots?from=2024-01-01&to=2024-06-30. Items
+// are left out; fetch a single snapshot for its contents.
+func (h *SnapshotHandler) GetAll(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
+	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+	from := c.Query("from")
+	to := c.Query("to")
+
+	filter := bson.M{}
+	date := bson.M{}
+	if from != "" {
+		date["$gte"] = from
+	}
+	if to != "" {
+		date["$lte"] = to
+	}
+	if len(date) > 0 {
+		filter["date"] = date
+	}
+
+	snapshots, total, err := h.service.GetAll(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch snapshots",
+		})
+		return
+	}
+
+	data := make([]map[string]interface{}, len(snapshots))
+	for i, snapshot := range snapshots {
+		data[i] = snapshot.ToSummary()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   total,
+	})
+}
+
+// GetByID handles GET /api/v1/snapshots/:id, where :id is a snapshot ID or
+// date (YYYY-MM-DD).
+func (h *SnapshotHandler) GetByID(c *gin.Context) {
+	snapshot, err := h.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		if err.Error() == "snapshot not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Snapshot not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch snapshot",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    snapshot.ToResponse(),
+	})
+}
+
+// Create handles POST /api/v1/snapshots. The body is optional:
+// {"date": "2024-05-31", "label": "May close"}; date defaults to today (UTC).
+// An existing snapshot for the date is kept unless ?replace=true.
+func (h *SnapshotHandler) Create(c *gin.Context) {
+	var req struct {
+		Date  string `json:"date"`
+		Label string `json:"label"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "Invalid request body: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	date := time.Now().UTC()
+	if req.Date != "" {
+		parsed, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "date must be formatted as YYYY-MM-DD",
+			})
+			return
+		}
+		date = parsed
+	}
+
+	snapshot, err := h.service.Take(c.Request.Context(), date, req.Label, models.TriggerManual, c.Query("replace") == "true")
+	if err != nil {
+		if errors.Is(err, service.ErrSnapshotExists) {
+			c.JSON(http.StatusConflict, gin.H{
+				"success": false,
+				"error":   "A snapshot already exists for " + date.Format("2006-01-02") + ", retry with replace=true to overwrite it",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to take snapshot",
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": "Snapshot taken successfully",
+		"data":    snapshot.ToSummary(),
+	})
+}
+
+func (h *SnapshotHandler) Delete(c *gin.Context) {
+	if err := h.service.Delete(c.Request.Context(), c.Param("id")); err != nil {
+		if err.Error() == "snapshot not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Snapshot not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to delete snapshot",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Snapshot deleted successfully",
+	})
+}
+
+// Diff handles GET /api/v1/snapshots/diff?from=<id|date>&to=<id|date>
+func (h *SnapshotHandler) Diff(c *gin.Context) {
+	from := c.Query("from")
+	to := c.Query("to")
+	if from == "" || to == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "from and to are required",
+		})
+		return
+	}
+
+	diff, err := h.service.Diff(c.Request.Context(), from, to)
+	if err != nil {
+		if err.Error() == "snapshot not found" {
+			c.JSON(http.StatusNotFound, gin.H{
+				"success": false,
+				"error":   "Snapshot not found",
+			})
+			return
+		}
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to compare snapshots",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    diff,
+	})
+}
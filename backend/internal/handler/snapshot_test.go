@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSnapshots(t *testing.T) {
+	api := newTestAPI(t)
+	risk := api.create("/risks", resourceCases[2].item(1))
+
+	expect(t, api.do(http.MethodPost, "/snapshots", map[string]string{"date": "01-05-2024"}), http.StatusBadRequest)
+	before := api.create("/snapshots", map[string]string{"date": "2024-05-01", "label": "Before launch"})
+	if counts, _ := before["counts"].(map[string]interface{}); counts["risks"] != 1.0 {
+		t.Fatalf("snapshot counts %v", before["counts"])
+	}
+	expect(t, api.do(http.MethodPost, "/snapshots", map[string]string{"date": "2024-05-01"}), http.StatusConflict)
+
+	// Replacing a snapshot keeps its ID
+	update := resourceCases[2].item(1)
+	update["severity"] = "critical"
+	expect(t, api.do(http.MethodPut, "/risks/"+risk["id"].(string), update), http.StatusOK)
+	replaced := api.create("/snapshots?replace=true", map[string]string{"date": "2024-05-01", "label": "Before launch"})
+	if replaced["id"] != before["id"] {
+		t.Fatalf("replaced snapshot ID = %v, want %v", replaced["id"], before["id"])
+	}
+
+	api.create("/risks", resourceCases[2].item(2))
+	after := api.create("/snapshots", map[string]string{"date": "2024-05-08"})
+	if after["label"] != "Snapshot 2024-05-08" {
+		t.Fatalf("default label = %v", after["label"])
+	}
+
+	listed := expect(t, api.do(http.MethodGet, "/snapshots", nil), http.StatusOK)
+	if items := listed.list(t); listed.Total != 2 || items[0]["id"] != after["id"] {
+		t.Fatalf("snapshots listed %v (total %d), want newest first", ids(items), listed.Total)
+	}
+	if total := expect(t, api.do(http.MethodGet, "/snapshots?from=2024-05-02", nil), http.StatusOK).Total; total != 1 {
+		t.Fatalf("snapshots from 2024-05-02 = %d, want 1", total)
+	}
+
+	got := expect(t, api.do(http.MethodGet, "/snapshots/"+before["id"].(string), nil), http.StatusOK).object(t)
+	if got["date"] != "2024-05-01" || got["dashboard"] == nil {
+		t.Fatalf("snapshot %v", got)
+	}
+
+	diff := expect(t, api.do(http.MethodGet, "/snapshots/diff?from=2024-05-01&to="+after["id"].(string), nil), http.StatusOK).object(t)
+	risks, _ := diff["risks"].(map[string]interface{})
+	if added, _ := risks["new"].([]interface{}); len(added) != 1 {
+		t.Fatalf("diff new risks %v", risks["new"])
+	}
+	expect(t, api.do(http.MethodGet, "/snapshots/diff?from=2024-05-01", nil), http.StatusBadRequest)
+	expect(t, api.do(http.MethodGet, "/snapshots/diff?from=2024-05-01&to=2023-01-01", nil), http.StatusNotFound)
+
+	expect(t, api.do(http.MethodDelete, "/snapshots/"+before["id"].(string), nil), http.StatusOK)
+	expect(t, api.do(http.MethodGet, "/snapshots/"+before["id"].(string), nil), http.StatusNotFound)
+	expect(t, api.do(http.MethodDelete, "/snapshots/"+before["id"].(string), nil), http.StatusNotFound)
+}
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports whether a component can serve traffic, returning an error
+// describing the problem when it cannot.
+type Check func(ctx context.Context) error
+
+type component struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks of the registered components.
+type Checker struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	components   []component
+	shuttingDown atomic.Bool
+}
+
+// New returns a Checker that gives each component check at most timeout.
+func New(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Register adds a component to the readiness report. Components are
+// reported in the order they were registered.
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.components = append(c.components, component{name: name, check: check})
+}
+
+// ShutDown marks the server as not ready, so load balancers stop sending
+// new requests while the connections in flight drain.
+func (c *Checker) ShutDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Status is the result of one component check.
+type Status struct {
+	Name    string
+	Ready   bool
+	Latency time.Duration
+	Error   string
+}
+
+func (s Status) ToResponse() map[string]interface{} {
+	status := "up"
+	if !s.Ready {
+		status = "down"
+	}
+	response := map[string]interface{}{
+		"name":       s.Name,
+		"status":     status,
+		"latency_ms": float64(s.Latency.Microseconds()) / 1000,
+	}
+	if s.Error != "" {
+		response["error"] = s.Error
+	}
+	return response
+}
+
+// Report is the readiness of the server and each of its components.
+type Report struct {
+	Ready        bool
+	ShuttingDown bool
+	Components   []Status
+}
+
+// Ready runs every component check concurrently. The server is ready when
+// all of them pass and shutdown has not begun.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.RLock()
+	components := append([]component(nil), c.components...)
+	c.mu.RUnlock()
+
+	report := Report{
+		ShuttingDown: c.shuttingDown.Load(),
+		Components:   make([]Status, len(components)),
+	}
+
+	var wg sync.WaitGroup
+	for i, comp := range components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := comp.check(checkCtx)
+			status := Status{Name: comp.name, Ready: err == nil, Latency: time.Since(start)}
+			if err != nil {
+				status.Error = err.Error()
+			}
+			report.Components[i] = status
+		}()
+	}
+	wg.Wait()
+
+	report.Ready = !report.ShuttingDown
+	for _, status := range report.Components {
+		if !status.Ready {
+			report.Ready = false
+		}
+	}
+	return report
+}
@@ -0,0 +1,148 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// HeaderKey is the request header carrying the client-chosen key.
+	HeaderKey = "Idempotency-Key"
+	// HeaderReplayed is set on responses served from a stored record.
+	HeaderReplayed = "Idempotent-Replayed"
+
+	maxKeyLength = 255
+
+	// maxBodySize caps the request body buffered to fingerprint the request,
+	// the same cap as import uploads.
+	maxBodySize = 10 << 20
+
+	// processingTimeout is how long a reservation may stay unfinished before
+	// it is considered abandoned (e.g. the server restarted mid-request).
+	processingTimeout = time.Minute
+)
+
+// Middleware makes POST requests carrying an Idempotency-Key safe to retry.
+// The first request with a key is executed and its response stored; retries
+// with the same key and the same request get the stored response back, and
+// reusing the key for a different request is rejected with 422.
+func Middleware(store Store) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderKey)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			abort(c, http.StatusBadRequest, "Idempotency-Key must be at most 255 characters")
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize)
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				abort(c, http.StatusRequestEntityTooLarge, "Request body must be at most 10MB")
+				return
+			}
+			abort(c, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash := requestHash(c.Request, body)
+
+		ctx := context.WithoutCancel(c.Request.Context())
+		owner := primitive.NewObjectID().Hex()
+		record, reserved, err := store.Reserve(ctx, key, hash, owner)
+		if err == nil && !reserved && record.State == StateProcessing && record.RequestHash == hash && time.Since(record.CreatedAt) > processingTimeout {
+			record, reserved, err = store.TakeOver(ctx, key, hash, owner, time.Now().Add(-processingTimeout))
+		}
+		if err != nil {
+			c.Error(err)
+			abort(c, http.StatusInternalServerError, "Failed to check idempotency key")
+			return
+		}
+
+		if !reserved {
+			switch {
+			case record.RequestHash != hash:
+				abort(c, http.StatusUnprocessableEntity, "Idempotency-Key has already been used for a different request")
+			case record.State == StateProcessing:
+				abort(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+			default:
+				for name, value := range record.Headers {
+					c.Header(name, value)
+				}
+				c.Header(HeaderReplayed, "true")
+				c.Data(record.StatusCode, record.Headers["Content-Type"], record.Body)
+				c.Abort()
+			}
+			return
+		}
+
+		// The key is released unless the response is stored, also when the
+		// handler panics, so retries are not turned away as still processing
+		// until the timeout. A reservation taken over by another request is
+		// left to that request.
+		completed := false
+		defer func() {
+			if !completed {
+				_ = store.Release(ctx, key, owner)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Server errors are not cached so the client can retry them
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+		completed = store.Complete(ctx, key, owner, recorder.Status(), recorder.Header(), recorder.body.Bytes()) == nil
+	}
+}
+
+// requestHash fingerprints the parts of a request that must match on retry.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	io.WriteString(h, "\n")
+	io.WriteString(h, r.URL.RequestURI())
+	io.WriteString(h, "\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func abort(c *gin.Context, status int, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"success": false,
+		"error":   message,
+	})
+}
+
+// responseRecorder copies the response body while it is written to the client.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
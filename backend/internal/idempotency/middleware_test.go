@@ -0,0 +1,160 @@
+package idempotency_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"naradai-backend/internal/idempotency"
+	"naradai-backend/internal/repository/memory"
+)
+
+// counter is an endpoint counting its calls, answering with status, or with
+// 201 when status is 0. It waits for release when release is set.
+type counter struct {
+	calls   atomic.Int32
+	status  atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newRouter(t *testing.T, store idempotency.Store) (*gin.Engine, *counter) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	endpoint := &counter{}
+	router := gin.New()
+	router.Use(idempotency.Middleware(store))
+	router.POST("/items", func(c *gin.Context) {
+		n := endpoint.calls.Add(1)
+		if endpoint.release != nil {
+			endpoint.started <- struct{}{}
+			<-endpoint.release
+		}
+		status := int(endpoint.status.Load())
+		if status == 0 {
+			status = http.StatusCreated
+		}
+		c.JSON(status, gin.H{"success": status < 400, "call": n})
+	})
+	return router, endpoint
+}
+
+func post(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(idempotency.HeaderKey, key)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddlewareReplaysTheStoredResponse(t *testing.T) {
+	router, endpoint := newRouter(t, memory.NewIdempotencyStore(memory.NewDatabase()))
+
+	first := post(router, "key-1", `{"title":"a"}`)
+	second := post(router, "key-1", `{"title":"a"}`)
+	if endpoint.calls.Load() != 1 {
+		t.Fatalf("endpoint called %d times, want 1", endpoint.calls.Load())
+	}
+	if second.Code != first.Code || second.Body.String() != first.Body.String() || second.Header().Get(idempotency.HeaderReplayed) != "true" {
+		t.Fatalf("replay = %d %s %v, want %d %s", second.Code, second.Body, second.Header(), first.Code, first.Body)
+	}
+
+	if rec := post(router, "key-1", `{"title":"b"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reusing the key for another body = %d, want 422", rec.Code)
+	}
+	if endpoint.calls.Load() != 1 {
+		t.Fatalf("endpoint called %d times, want 1", endpoint.calls.Load())
+	}
+}
+
+func TestMiddlewareRejectsARetryWhileProcessing(t *testing.T) {
+	router, endpoint := newRouter(t, memory.NewIdempotencyStore(memory.NewDatabase()))
+	endpoint.started = make(chan struct{})
+	endpoint.release = make(chan struct{})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post(router, "key-1", `{}`) }()
+	<-endpoint.started
+
+	if rec := post(router, "key-1", `{}`); rec.Code != http.StatusConflict {
+		t.Fatalf("retry while processing = %d, want 409", rec.Code)
+	}
+	close(endpoint.release)
+	if rec := <-done; rec.Code != http.StatusCreated {
+		t.Fatalf("first request = %d, want 201", rec.Code)
+	}
+}
+
+func TestMiddlewareDoesNotStoreServerErrors(t *testing.T) {
+	router, endpoint := newRouter(t, memory.NewIdempotencyStore(memory.NewDatabase()))
+
+	endpoint.status.Store(http.StatusInternalServerError)
+	if rec := post(router, "key-1", `{}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("failing request = %d, want 500", rec.Code)
+	}
+	endpoint.status.Store(0)
+	rec := post(router, "key-1", `{}`)
+	if rec.Code != http.StatusCreated || rec.Header().Get(idempotency.HeaderReplayed) != "" || endpoint.calls.Load() != 2 {
+		t.Fatalf("retry after a 500 = %d replayed %q after %d calls, want a new 201", rec.Code, rec.Header().Get(idempotency.HeaderReplayed), endpoint.calls.Load())
+	}
+}
+
+func TestStoreTakeOver(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewIdempotencyStore(memory.NewDatabase())
+	if _, reserved, err := store.Reserve(ctx, "key-1", "hash", "abandoned"); err != nil || !reserved {
+		t.Fatalf("Reserve = %v, %v", reserved, err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	staleBefore := time.Now()
+
+	if _, reserved, err := store.TakeOver(ctx, "key-1", "other-hash", "other", staleBefore); err != nil || reserved {
+		t.Fatalf("TakeOver for another request = %v, %v, want not reserved", reserved, err)
+	}
+
+	// Of the retries racing for the abandoned reservation only one wins
+	var wg sync.WaitGroup
+	var winners atomic.Int32
+	var winner atomic.Value
+	for _, owner := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, reserved, err := store.TakeOver(ctx, "key-1", "hash", owner, staleBefore)
+			if err != nil {
+				t.Errorf("TakeOver: %v", err)
+			}
+			if reserved {
+				winners.Add(1)
+				winner.Store(owner)
+			}
+		}()
+	}
+	wg.Wait()
+	if winners.Load() != 1 {
+		t.Fatalf("%d retries took over the reservation, want 1", winners.Load())
+	}
+
+	// The abandoned request can neither release nor complete it any more
+	if err := store.Release(ctx, "key-1", "abandoned"); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if err := store.Complete(ctx, "key-1", "abandoned", http.StatusCreated, http.Header{}, nil); !errors.Is(err, idempotency.ErrNotReserved) {
+		t.Fatalf("Complete by the previous owner = %v, want ErrNotReserved", err)
+	}
+	if err := store.Complete(ctx, "key-1", winner.Load().(string), http.StatusCreated, http.Header{}, []byte(`{}`)); err != nil {
+		t.Fatalf("Complete by the new owner: %v", err)
+	}
+	record, reserved, err := store.Reserve(ctx, "key-1", "hash", "late")
+	if err != nil || reserved || record.State != idempotency.StateCompleted || record.StatusCode != http.StatusCreated {
+		t.Fatalf("record after completing = %+v, %v, %v", record, reserved, err)
+	}
+}
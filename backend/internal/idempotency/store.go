@@ -0,0 +1,176 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type State string
+
+const (
+	StateProcessing State = "processing"
+	StateCompleted  State = "completed"
+)
+
+// ErrNotReserved is returned by Store.Complete when the key is no longer
+// reserved by the owner completing it, e.g. because another request took
+// over the abandoned reservation.
+var ErrNotReserved = errors.New("idempotency key is not reserved by this request")
+
+// Record is the stored outcome of a request made with an Idempotency-Key.
+type Record struct {
+	Key         string    `bson:"_id"`
+	RequestHash string    `bson:"request_hash"`
+	State       State     `bson:"state"`
+	Owner       string    `bson:"owner,omitempty"` // the request holding a processing reservation
+	StatusCode  int       `bson:"status_code,omitempty"`
+	Headers     Headers   `bson:"headers,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"created_at"`
+	ExpiresAt   time.Time `bson:"expires_at"`
+}
+
+// Headers are the response headers replayed with a stored response.
+type Headers map[string]string
+
+// replayedHeaders lists the response headers worth storing for a replay.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Store keeps the idempotency records. A reservation belongs to the request
+// that made it, identified by an owner token, and only that request can
+// complete or release it.
+type Store interface {
+	// Reserve claims key for a new request. When the key is already known,
+	// the existing record is returned and reserved is false.
+	Reserve(ctx context.Context, key, requestHash, owner string) (record *Record, reserved bool, err error)
+	// TakeOver claims a reservation for the same request that has been
+	// processing since before staleBefore, as one atomic write, so only one
+	// retry can take over an abandoned request. When the reservation is not
+	// stale, or another retry took it over first, the record as it is now is
+	// returned and reserved is false.
+	TakeOver(ctx context.Context, key, requestHash, owner string, staleBefore time.Time) (record *Record, reserved bool, err error)
+	// Complete stores the response of the request owning the reservation of
+	// key, or returns ErrNotReserved when owner no longer holds it.
+	Complete(ctx context.Context, key, owner string, statusCode int, header http.Header, body []byte) error
+	// Release forgets the reservation of key held by owner, so that the
+	// request can be retried. A reservation owner no longer holds is kept.
+	Release(ctx context.Context, key, owner string) error
+}
+
+// ReplayedHeaders returns the response headers stored for a replay.
+func ReplayedHeaders(header http.Header) Headers {
+	headers := Headers{}
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+	return headers
+}
+
+// MongoStore keeps idempotency records in MongoDB. Records are removed by a
+// TTL index once they expire.
+type MongoStore struct {
+	collection *mongo.Collection
+	ttl        time.Duration
+}
+
+func NewMongoStore(db *mongo.Database, ttl time.Duration) *MongoStore {
+	return &MongoStore{
+		collection: db.Collection("idempotency_keys"),
+		ttl:        ttl,
+	}
+}
+
+// EnsureIndexes creates the TTL index that expires old records.
+func (s *MongoStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+func (s *MongoStore) Reserve(ctx context.Context, key, requestHash, owner string) (record *Record, reserved bool, err error) {
+	now := time.Now()
+	record = &Record{
+		Key:         key,
+		RequestHash: requestHash,
+		State:       StateProcessing,
+		Owner:       owner,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+
+	_, err = s.collection.InsertOne(ctx, record)
+	if err == nil {
+		return record, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	var existing Record
+	err = s.collection.FindOne(ctx, bson.M{"_id": key}).Decode(&existing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Expired or released between the insert and the lookup
+		return s.Reserve(ctx, key, requestHash, owner)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+func (s *MongoStore) TakeOver(ctx context.Context, key, requestHash, owner string, staleBefore time.Time) (*Record, bool, error) {
+	now := time.Now()
+	var record Record
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{
+		"_id":          key,
+		"state":        StateProcessing,
+		"request_hash": requestHash,
+		"created_at":   bson.M{"$lt": staleBefore},
+	}, bson.M{
+		"$set": bson.M{"owner": owner, "created_at": now, "expires_at": now.Add(s.ttl)},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&record)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Not stale, or no longer there: answer as for a new request
+		return s.Reserve(ctx, key, requestHash, owner)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &record, true, nil
+}
+
+func (s *MongoStore) Complete(ctx context.Context, key, owner string, statusCode int, header http.Header, body []byte) error {
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": key, "owner": owner, "state": StateProcessing}, bson.M{
+		"$set": bson.M{
+			"state":       StateCompleted,
+			"status_code": statusCode,
+			"headers":     ReplayedHeaders(header),
+			"body":        body,
+		},
+		"$unset": bson.M{"owner": ""},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotReserved
+	}
+	return nil
+}
+
+func (s *MongoStore) Release(ctx context.Context, key, owner string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": key, "owner": owner, "state": StateProcessing})
+	return err
+}
+
+var _ Store = (*MongoStore)(nil)
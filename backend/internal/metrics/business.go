@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// businessTimeout bounds the queries run on each scrape.
+const businessTimeout = 5 * time.Second
+
+var (
+	openRisksDesc = prometheus.NewDesc(
+		"naradai_open_risks",
+		"Active risks by severity.",
+		[]string{"severity"}, nil,
+	)
+	priorityActionsDesc = prometheus.NewDesc(
+		"naradai_priority_actions",
+		"Priority actions by status.",
+		[]string{"status"}, nil,
+	)
+)
+
+// BusinessCollector reports dashboard KPIs, queried from the database when
+// Prometheus scrapes rather than kept up to date on every write.
+type BusinessCollector struct {
+	repo repository.StatMetricStore
+}
+
+func NewBusinessCollector(repo repository.StatMetricStore) *BusinessCollector {
+	return &BusinessCollector{repo: repo}
+}
+
+func (c *BusinessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- openRisksDesc
+	ch <- priorityActionsDesc
+}
+
+// Collect reports every severity and status, including those with no items,
+// so alerts on them do not go stale when the last one is closed.
+func (c *BusinessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), businessTimeout)
+	defer cancel()
+
+	if risks, err := c.repo.RiskCountsBySeverity(ctx); err != nil {
+		ch <- prometheus.NewInvalidMetric(openRisksDesc, err)
+	} else {
+		for _, severity := range []models.RiskSeverity{models.RiskSeverityCritical, models.RiskSeverityHigh, models.RiskSeverityMedium, models.RiskSeverityLow} {
+			ch <- prometheus.MustNewConstMetric(openRisksDesc, prometheus.GaugeValue, float64(risks[severity]), string(severity))
+		}
+	}
+
+	if actions, err := c.repo.ActionCountsByStatus(ctx); err != nil {
+		ch <- prometheus.NewInvalidMetric(priorityActionsDesc, err)
+	} else {
+		for _, status := range []models.Status{models.StatusNotStarted, models.StatusInProgress, models.StatusCompleted} {
+			ch <- prometheus.MustNewConstMetric(priorityActionsDesc, prometheus.GaugeValue, float64(actions[status]), string(status))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// CommandMonitor returns a MongoDB command monitor recording the duration of
+// every command by collection and command name. Pass it to the client
+// options in main.go.
+func (m *Metrics) CommandMonitor() *event.CommandMonitor {
+	// The collection is only known when a command starts, so it is kept by
+	// request ID until the command finishes.
+	var collections sync.Map
+
+	finish := func(requestID int64, command string, seconds float64, status string) {
+		collection := "none"
+		if value, ok := collections.LoadAndDelete(requestID); ok {
+			collection = value.(string)
+		}
+		m.mongoDuration.WithLabelValues(collection, command, status).Observe(seconds)
+	}
+
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, e *event.CommandStartedEvent) {
+			collections.Store(e.RequestID, commandCollection(e.CommandName, e.Command))
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, e.CommandName, e.Duration.Seconds(), "success")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.CommandName, e.Duration.Seconds(), "error")
+		},
+	}
+}
+
+// commandCollection returns the collection a command operates on. CRUD
+// commands name it as the value of their first element, as in
+// {find: "risks", ...}, and getMore in its collection field; database-level
+// commands such as ping have none.
+func commandCollection(name string, command bson.Raw) string {
+	key := name
+	if name == "getMore" {
+		key = "collection"
+	}
+	value, err := command.LookupErr(key)
+	if err != nil {
+		return "none"
+	}
+	if collection, ok := value.StringValueOK(); ok {
+		return collection
+	}
+	return "none"
+}
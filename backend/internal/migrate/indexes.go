@@ -0,0 +1,153 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// index declares an index on a collection. Indexes are named the way MongoDB
+// names them by default, so those the repositories create on startup with
+// EnsureIndexes are the same indexes and creating them again is a no-op.
+type index struct {
+	keys   bson.D
+	unique bool
+	// ttl expires documents at the time in the indexed field
+	ttl bool
+	// ensured indexes are also created by EnsureIndexes on startup
+	ensured bool
+}
+
+func (i index) name() string {
+	parts := make([]string, len(i.keys))
+	for n, key := range i.keys {
+		parts[n] = fmt.Sprintf("%s_%v", key.Key, key.Value)
+	}
+	return strings.Join(parts, "_")
+}
+
+func (i index) model() mongo.IndexModel {
+	opts := options.Index().SetName(i.name())
+	if i.unique {
+		opts.SetUnique(true)
+	}
+	if i.ttl {
+		opts.SetExpireAfterSeconds(0)
+	}
+	return mongo.IndexModel{Keys: i.keys, Options: opts}
+}
+
+// listOrder serves the dashboard lists, which filter on is_active and sort by
+// order.
+var listOrder = index{keys: bson.D{{Key: "is_active", Value: 1}, {Key: "order", Value: 1}}}
+
+// indexes are the indexes of every collection, matching the filters and sorts
+// of the repositories.
+var indexes = map[string][]index{
+	"priority_actions": {
+		{keys: bson.D{{Key: "priority", Value: 1}, {Key: "status", Value: 1}}},
+		{keys: bson.D{{Key: "status", Value: 1}}},
+		{keys: bson.D{{Key: "created_at", Value: -1}}},
+	},
+	"dashboard_stats":      {listOrder},
+	"sentiment_trends":     {listOrder},
+	"discussion_topics":    {listOrder},
+	"competitive_analyses": {listOrder},
+	"conversation_clusters": {
+		listOrder,
+		{keys: bson.D{{Key: "is_active", Value: 1}, {Key: "updated_at", Value: -1}}},
+	},
+	"risks": {
+		listOrder,
+		{keys: bson.D{{Key: "is_active", Value: 1}, {Key: "severity", Value: 1}}},
+	},
+	"opportunities": {
+		listOrder,
+		{keys: bson.D{{Key: "potential", Value: 1}}},
+	},
+	"dashboard_stat_points": {
+		{keys: bson.D{{Key: "stat_id", Value: 1}, {Key: "at", Value: 1}}, unique: true, ensured: true},
+	},
+	"snapshots": {
+		{keys: bson.D{{Key: "date", Value: 1}}, unique: true, ensured: true},
+	},
+	"relations": {
+		{keys: bson.D{
+			{Key: "from.type", Value: 1}, {Key: "from.id", Value: 1},
+			{Key: "to.type", Value: 1}, {Key: "to.id", Value: 1},
+			{Key: "type", Value: 1},
+		}, unique: true, ensured: true},
+		{keys: bson.D{{Key: "to.type", Value: 1}, {Key: "to.id", Value: 1}}, ensured: true},
+	},
+	"evidence": {
+		{keys: bson.D{{Key: "item.type", Value: 1}, {Key: "item.id", Value: 1}, {Key: "created_at", Value: 1}}, ensured: true},
+	},
+	"idempotency_keys": {
+		{keys: bson.D{{Key: "expires_at", Value: 1}}, ttl: true, ensured: true},
+	},
+	"report_schedules": {
+		{keys: bson.D{{Key: "is_active", Value: 1}, {Key: "next_run_at", Value: 1}}},
+	},
+	"report_runs": {
+		{keys: bson.D{{Key: "schedule_id", Value: 1}, {Key: "started_at", Value: -1}}},
+	},
+}
+
+func createIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, declared := range indexes {
+		models := make([]mongo.IndexModel, len(declared))
+		for i, idx := range declared {
+			models[i] = idx.model()
+		}
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return fmt.Errorf("%s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+// dropIndexes removes the declared indexes except those the server ensures on
+// startup, which it relies on for uniqueness and expiry.
+func dropIndexes(ctx context.Context, db *mongo.Database) error {
+	for collection, declared := range indexes {
+		for _, idx := range declared {
+			if idx.ensured {
+				continue
+			}
+			_, err := db.Collection(collection).Indexes().DropOne(ctx, idx.name())
+			if err != nil && !isIndexNotFound(err) {
+				return fmt.Errorf("%s: %w", collection, err)
+			}
+		}
+	}
+	return nil
+}
+
+// isIndexNotFound reports whether err is MongoDB's IndexNotFound (27) or
+// NamespaceNotFound (26), both meaning there is nothing to drop.
+func isIndexNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && (cmdErr.Code == 27 || cmdErr.Code == 26)
+}
+
+// actionOrder serves the priority action list, which sorts by order like the
+// other dashboard lists since priority actions can be reordered.
+var actionOrder = index{keys: bson.D{{Key: "order", Value: 1}, {Key: "created_at", Value: -1}}}
+
+func createActionOrderIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("priority_actions").Indexes().CreateOne(ctx, actionOrder.model())
+	return err
+}
+
+func dropActionOrderIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("priority_actions").Indexes().DropOne(ctx, actionOrder.name())
+	if err != nil && !isIndexNotFound(err) {
+		return err
+	}
+	return nil
+}
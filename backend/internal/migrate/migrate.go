@@ -0,0 +1,251 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrIrreversible is returned when rolling back a migration without a Down.
+var ErrIrreversible = errors.New("migration cannot be rolled back")
+
+// ErrLocked is returned when another process kept the migration lock for
+// longer than lockWait.
+var ErrLocked = errors.New("another process is running migrations")
+
+const (
+	lockTTL  = 10 * time.Minute
+	lockWait = 2 * time.Minute
+)
+
+// Migration is one versioned change to the database. Up should be safe to run
+// again after a partial failure, since a migration is only recorded as applied
+// once Up returns. Down undoes Up and is nil for irreversible migrations.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+	Down        func(ctx context.Context, db *mongo.Database) error
+}
+
+// State is a migration and when it was applied, if it has been.
+type State struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type record struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+// Migrator applies migrations in version order, recording each applied one in
+// the schema_migrations collection.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+	applied    *mongo.Collection
+	locks      *mongo.Collection
+	owner      string
+}
+
+func New(db *mongo.Database, migrations []Migration) *Migrator {
+	sorted := append([]Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+
+	hostname, _ := os.Hostname()
+	return &Migrator{
+		db:         db,
+		migrations: sorted,
+		applied:    db.Collection("schema_migrations"),
+		locks:      db.Collection("schema_migrations_lock"),
+		owner:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Status lists every known migration with when it was applied.
+func (m *Migrator) Status(ctx context.Context) ([]State, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states := make([]State, len(m.migrations))
+	for i, migration := range m.migrations {
+		states[i] = State{Migration: migration}
+		if at, ok := applied[migration.Version]; ok {
+			states[i].AppliedAt = &at
+		}
+	}
+	return states, nil
+}
+
+// Version returns the highest applied migration version, or 0 when none has
+// been applied.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range applied {
+		version = max(version, v)
+	}
+	return version, nil
+}
+
+// Up applies the pending migrations up to and including target, or all of
+// them when target is 0, and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context, target int) ([]Migration, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	if err := m.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.release()
+
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if target > 0 && migration.Version > target {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		_, err := m.applied.InsertOne(ctx, record{
+			Version:     migration.Version,
+			Description: migration.Description,
+			AppliedAt:   time.Now(),
+		})
+		if err != nil {
+			return done, fmt.Errorf("record migration %d: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down rolls back the given number of most recently applied migrations, newest
+// first, and returns the ones it rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	if err := m.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer m.release()
+
+	applied, err := m.appliedVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Description, ErrIrreversible)
+		}
+		if err := migration.Down(ctx, m.db); err != nil {
+			return done, fmt.Errorf("roll back migration %d (%s): %w", migration.Version, migration.Description, err)
+		}
+		if _, err := m.applied.DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+			return done, fmt.Errorf("unrecord migration %d: %w", migration.Version, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// check rejects duplicate or non-positive versions, which would make the
+// recorded state ambiguous.
+func (m *Migrator) check() error {
+	for i, migration := range m.migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration %q has version %d; versions start at 1", migration.Description, migration.Version)
+		}
+		if i > 0 && m.migrations[i-1].Version == migration.Version {
+			return fmt.Errorf("migration version %d is used twice", migration.Version)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	cursor, err := m.applied.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]time.Time, len(records))
+	for _, r := range records {
+		applied[r.Version] = r.AppliedAt
+	}
+	return applied, nil
+}
+
+// acquire takes the migration lock, waiting while another process holds it,
+// so replicas starting together apply each migration once. The lock is a
+// lease like the scheduler's, so a crashed process does not hold it forever.
+func (m *Migrator) acquire(ctx context.Context) error {
+	deadline := time.Now().Add(lockWait)
+	for {
+		now := time.Now()
+		_, err := m.locks.UpdateOne(ctx,
+			bson.M{
+				"_id": "lock",
+				"$or": bson.A{
+					bson.M{"owner": m.owner},
+					bson.M{"expires_at": bson.M{"$lte": now}},
+				},
+			},
+			bson.M{"$set": bson.M{"owner": m.owner, "acquired_at": now, "expires_at": now.Add(lockTTL)}},
+			options.Update().SetUpsert(true),
+		)
+		if err == nil {
+			return nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return ErrLocked
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (m *Migrator) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	m.locks.DeleteOne(ctx, bson.M{"_id": "lock", "owner": m.owner})
+}
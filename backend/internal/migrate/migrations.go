@@ -0,0 +1,24 @@
+package migrate
+
+// Migrations are the migrations of the schema, in version order. Append new
+// ones with the next version; never renumber or edit one that has shipped.
+var Migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create indexes for every collection",
+		Up:          createIndexes,
+		Down:        dropIndexes,
+	},
+	{
+		Version:     2,
+		Description: "add JSON schema validators to the dashboard collections",
+		Up:          setValidators,
+		Down:        removeValidators,
+	},
+	{
+		Version:     3,
+		Description: "index priority actions by order",
+		Up:          createActionOrderIndex,
+		Down:        dropActionOrderIndex,
+	},
+}
@@ -0,0 +1,201 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	stringType   = bson.M{"bsonType": "string"}
+	numberType   = bson.M{"bsonType": "number"}
+	boolType     = bson.M{"bsonType": "bool"}
+	dateType     = bson.M{"bsonType": "date"}
+	arrayType    = bson.M{"bsonType": bson.A{"array", "null"}}
+	percentType  = bson.M{"bsonType": "number", "minimum": 0, "maximum": 100}
+	trendType    = enum("increasing", "stable", "decreasing")
+	severityType = enum("critical", "high", "medium", "low")
+)
+
+func enum(values ...string) bson.M {
+	return bson.M{"bsonType": "string", "enum": values}
+}
+
+// document is the $jsonSchema of a collection. The fields every document has
+// are listed in common; required lists the fields the service validates as
+// required, so a document written around the API, such as by a script, is
+// held to the same rules.
+func document(required []string, properties bson.M) bson.M {
+	common := bson.M{
+		"_id":        bson.M{"bsonType": "objectId"},
+		"version":    numberType,
+		"created_at": dateType,
+		"updated_at": dateType,
+	}
+	for field, schema := range properties {
+		common[field] = schema
+	}
+	return bson.M{
+		"bsonType":   "object",
+		"required":   required,
+		"properties": common,
+	}
+}
+
+// listed adds the is_active and order fields of the dashboard lists.
+func listed(properties bson.M) bson.M {
+	properties["is_active"] = boolType
+	properties["order"] = numberType
+	return properties
+}
+
+// schemas are the validators of the dashboard collections, mirroring the
+// validate tags of the models.
+var schemas = map[string]bson.M{
+	"priority_actions": document(
+		[]string{"priority", "title", "description", "impact", "effort", "recommendation", "trend", "icon"},
+		bson.M{
+			"priority":       enum("critical", "high", "medium"),
+			"title":          stringType,
+			"description":    stringType,
+			"impact":         enum("Critical", "High", "Medium", "Low"),
+			"effort":         enum("Low", "Medium", "High"),
+			"recommendation": stringType,
+			"mentions":       numberType,
+			"sentiment":      numberType,
+			"trend":          trendType,
+			"icon":           stringType,
+			// Replacing an action without a status clears it
+			"status": enum("", "not-started", "in-progress", "completed"),
+		},
+	),
+	"dashboard_stats": document(
+		[]string{"label", "icon"},
+		listed(bson.M{
+			"label":    stringType,
+			"value":    stringType,
+			"change":   stringType,
+			"trend":    enum("", "up", "down"),
+			"icon":     stringType,
+			"unit":     enum("", "number", "percent", "currency"),
+			"currency": stringType,
+			"binding":  bson.M{"bsonType": bson.A{"object", "null"}},
+		}),
+	),
+	"sentiment_trends": document(
+		[]string{"title", "period"},
+		listed(bson.M{
+			"title":            stringType,
+			"period":           stringType,
+			"positive_percent": percentType,
+			"negative_percent": percentType,
+			"neutral_percent":  percentType,
+			"trend_data":       arrayType,
+		}),
+	),
+	"discussion_topics": document(
+		[]string{"name"},
+		listed(bson.M{
+			"name":            stringType,
+			"volume":          numberType,
+			"sentiment_score": numberType,
+			"color":           stringType,
+		}),
+	),
+	"competitive_analyses": document(
+		[]string{"name"},
+		listed(bson.M{
+			"name":           stringType,
+			"share_of_voice": percentType,
+			"sentiment":      percentType,
+			"engagement":     numberType,
+			"position":       stringType,
+			"gap_to_leader":  stringType,
+		}),
+	),
+	"conversation_clusters": document(
+		[]string{"theme"},
+		listed(bson.M{
+			"theme":     stringType,
+			"size":      numberType,
+			"sentiment": numberType,
+			"trend":     enum("up", "down", "stable"),
+			"keywords":  arrayType,
+		}),
+	),
+	"risks": document(
+		[]string{"title", "description", "severity", "impact_assessment", "trend"},
+		listed(bson.M{
+			"title":               stringType,
+			"description":         stringType,
+			"severity":            severityType,
+			"probability":         percentType,
+			"impact_assessment":   stringType,
+			"trend":               trendType,
+			"indicators":          arrayType,
+			"mitigation_strategy": arrayType,
+		}),
+	),
+	"opportunities": document(
+		[]string{"title", "description", "potential", "timeframe", "category", "trend"},
+		listed(bson.M{
+			"title":               stringType,
+			"description":         stringType,
+			"potential":           enum("high", "medium", "low"),
+			"confidence_score":    percentType,
+			"timeframe":           enum("Short-term", "Medium-term", "Long-term"),
+			"category":            stringType,
+			"trend":               trendType,
+			"key_metrics":         arrayType,
+			"recommended_actions": arrayType,
+		}),
+	),
+}
+
+// setValidators installs the schemas with the moderate validation level, so
+// documents that were already invalid can still be updated while new writes
+// are checked.
+func setValidators(ctx context.Context, db *mongo.Database) error {
+	for collection, schema := range schemas {
+		validator := bson.M{"$jsonSchema": schema}
+		err := db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "validator", Value: validator},
+			{Key: "validationLevel", Value: "moderate"},
+			{Key: "validationAction", Value: "error"},
+		}).Err()
+		if isNamespaceNotFound(err) {
+			err = db.CreateCollection(ctx, collection, options.CreateCollection().
+				SetValidator(validator).
+				SetValidationLevel("moderate").
+				SetValidationAction("error"))
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+func removeValidators(ctx context.Context, db *mongo.Database) error {
+	for collection := range schemas {
+		err := db.RunCommand(ctx, bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "validator", Value: bson.M{}},
+			{Key: "validationLevel", Value: "off"},
+		}).Err()
+		if err != nil && !isNamespaceNotFound(err) {
+			return fmt.Errorf("%s: %w", collection, err)
+		}
+	}
+	return nil
+}
+
+func isNamespaceNotFound(err error) bool {
+	var cmdErr mongo.CommandError
+	return errors.As(err, &cmdErr) && cmdErr.Code == 26
+}
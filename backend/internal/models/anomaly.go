@@ -0,0 +1,36 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// PointAnomaly marks one series of a data point as anomalous
+type PointAnomaly struct {
+	Series    string  `json:"series"` // e.g., "positive", "negative" or "mentions"
+	Expected  float64 `json:"expected"`
+	ZScore    float64 `json:"z_score"`
+	Direction string  `json:"direction"` // "spike" or "drop"
+}
+
+// Anomaly is an anomalous point found in the time series of a resource. It
+// is computed on request and not stored.
+type Anomaly struct {
+	PointAnomaly
+	Source   string             `json:"source"` // "sentiment_trends" or "dashboard_stats"
+	SourceID primitive.ObjectID `json:"source_id"`
+	Title    string             `json:"title"`
+	Date     string             `json:"date"`
+	Value    float64            `json:"value"`
+}
+
+func (a *Anomaly) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"source":    a.Source,
+		"source_id": a.SourceID.Hex(),
+		"title":     a.Title,
+		"series":    a.Series,
+		"date":      a.Date,
+		"value":     a.Value,
+		"expected":  a.Expected,
+		"z_score":   a.ZScore,
+		"direction": a.Direction,
+	}
+}
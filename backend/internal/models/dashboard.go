@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Dashboard holds every item currently shown on the dashboard. Snapshots
+// store a frozen copy of it.
+type Dashboard struct {
+	GeneratedAt          time.Time             `json:"generated_at" bson:"generated_at"`
+	Stats                []DashboardStat       `json:"dashboard_stats" bson:"dashboard_stats"`
+	PriorityActions      []PriorityAction      `json:"priority_actions" bson:"priority_actions"`
+	Risks                []Risk                `json:"risks" bson:"risks"`
+	Opportunities        []Opportunity         `json:"opportunities" bson:"opportunities"`
+	SentimentTrends      []SentimentTrend      `json:"sentiment_trends" bson:"sentiment_trends"`
+	DiscussionTopics     []DiscussionTopic     `json:"discussion_topics" bson:"discussion_topics"`
+	CompetitiveAnalyses  []CompetitiveAnalysis `json:"competitive_analyses" bson:"competitive_analyses"`
+	ConversationClusters []ConversationCluster `json:"conversation_clusters" bson:"conversation_clusters"`
+}
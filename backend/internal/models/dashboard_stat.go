@@ -11,36 +11,78 @@ type StatTrend string
 const (
 	StatTrendUp   StatTrend = "up"
 	StatTrendDown StatTrend = "down"
+	StatTrendFlat StatTrend = "flat"
 )
 
+// StatUnit controls how recorded values are displayed
+type StatUnit string
+
+const (
+	StatUnitNumber   StatUnit = "number"   // 12400 -> "12.4K"
+	StatUnitPercent  StatUnit = "percent"  // 68.5 -> "68.5%"
+	StatUnitCurrency StatUnit = "currency" // 2500000 -> "Rp 2.5M" with currency "IDR"
+)
+
+// StatMetric names a query the server can evaluate for a bound stat
+type StatMetric string
+
+const (
+	StatMetricMentionCount     StatMetric = "mention_count"     // mentions across active conversation clusters
+	StatMetricAverageSentiment StatMetric = "average_sentiment" // mention-weighted sentiment of active conversation clusters
+	StatMetricRiskCount        StatMetric = "risk_count"        // active risks, optionally of one severity
+	StatMetricActionCompletion StatMetric = "action_completion" // percentage of priority actions completed
+)
+
+// StatBinding ties a stat to a metric query. Window limits the mention
+// metrics to clusters updated within it (e.g. "7d", "24h"); empty counts all
+// of them. A live binding is evaluated on every read, any other binding on
+// the server's refresh schedule.
+type StatBinding struct {
+	Metric   StatMetric   `json:"metric" bson:"metric" validate:"required,oneof=mention_count average_sentiment risk_count action_completion"`
+	Window   string       `json:"window" bson:"window"`
+	Severity RiskSeverity `json:"severity" bson:"severity" validate:"omitempty,oneof=critical high medium low"`
+	Live     bool         `json:"live" bson:"live"`
+}
+
+// DashboardStat is a headline figure on the dashboard. Once values are
+// recorded in its time series, Value, Change and Trend are computed by the
+// server from the latest two points. A stat with a Binding records its points
+// from the bound metric, so Value, Change and Trend may be left empty.
 type DashboardStat struct {
-	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Label     string             `json:"label" bson:"label" validate:"required,min=3,max=100"`
-	Value     string             `json:"value" bson:"value" validate:"required,min=1,max=50"`
-	Change    string             `json:"change" bson:"change" validate:"required,min=1,max=20"`
-	Trend     StatTrend          `json:"trend" bson:"trend" validate:"required,oneof=up down"`
-	Icon      string             `json:"icon" bson:"icon" validate:"required"`
-	Order     int                `json:"order" bson:"order" validate:"min=0"`
-	IsActive  bool               `json:"is_active" bson:"is_active"`
-	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Label        string             `json:"label" bson:"label" validate:"required,min=3,max=100"`
+	Value        string             `json:"value" bson:"value" validate:"required_without=Binding,max=50"`
+	Change       string             `json:"change" bson:"change" validate:"required_without=Binding,max=20"`
+	Trend        StatTrend          `json:"trend" bson:"trend" validate:"required_without=Binding,omitempty,oneof=up down flat"`
+	Icon         string             `json:"icon" bson:"icon" validate:"required"`
+	Unit         StatUnit           `json:"unit" bson:"unit" validate:"omitempty,oneof=number percent currency"`
+	Currency     string             `json:"currency" bson:"currency" validate:"omitempty,len=3"` // ISO 4217 code, e.g. "IDR"
+	NumericValue *float64           `json:"numeric_value" bson:"numeric_value,omitempty"`        // latest value of the time series, set by the server
+	Binding      *StatBinding       `json:"binding" bson:"binding"`
+	Order        int                `json:"order" bson:"order" validate:"min=0"`
+	IsActive     bool               `json:"is_active" bson:"is_active"`
+	Version      int64              `json:"version" bson:"version"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
 }
 
 // ToResponse converts ObjectID to string for JSON response
 func (ds *DashboardStat) ToResponse() map[string]interface{} {
 	return map[string]interface{}{
-		"id":         ds.ID.Hex(),
-		"label":      ds.Label,
-		"value":      ds.Value,
-		"change":     ds.Change,
-		"trend":      ds.Trend,
-		"icon":       ds.Icon,
-		"order":      ds.Order,
-		"is_active":  ds.IsActive,
-		"created_at": ds.CreatedAt.Format(time.RFC3339),
-		"updated_at": ds.UpdatedAt.Format(time.RFC3339),
+		"id":            ds.ID.Hex(),
+		"label":         ds.Label,
+		"value":         ds.Value,
+		"change":        ds.Change,
+		"trend":         ds.Trend,
+		"icon":          ds.Icon,
+		"unit":          ds.Unit,
+		"currency":      ds.Currency,
+		"numeric_value": ds.NumericValue,
+		"binding":       ds.Binding,
+		"order":         ds.Order,
+		"is_active":     ds.IsActive,
+		"version":       ds.Version,
+		"created_at":    ds.CreatedAt.Format(time.RFC3339),
+		"updated_at":    ds.UpdatedAt.Format(time.RFC3339),
 	}
 }
-
-
-
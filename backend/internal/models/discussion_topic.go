@@ -15,6 +15,9 @@ type DiscussionTopic struct {
 	Color          string             `json:"color" bson:"color"`                     // Gradient color for the bar
 	IsActive       bool               `json:"is_active" bson:"is_active"`
 	Order          int                `json:"order" bson:"order"`
+	FlaggedReason  string             `json:"flagged_reason" bson:"flagged_reason"`
+	FlaggedAt      *time.Time         `json:"flagged_at" bson:"flagged_at"`
+	Version        int64              `json:"version" bson:"version"`
 	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
 }
@@ -28,8 +31,10 @@ func (d *DiscussionTopic) ToResponse() map[string]interface{} {
 		"color":           d.Color,
 		"is_active":       d.IsActive,
 		"order":           d.Order,
+		"flagged_reason":  d.FlaggedReason,
+		"flagged_at":      formatOptionalTime(d.FlaggedAt),
+		"version":         d.Version,
 		"created_at":      d.CreatedAt.Format(time.RFC3339),
 		"updated_at":      d.UpdatedAt.Format(time.RFC3339),
 	}
 }
-
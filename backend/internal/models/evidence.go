@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Evidence is a sample mention attached to an item to show what people
+// actually posted. AutoSelected marks mentions picked by the server from a
+// set of candidates rather than added by hand.
+type Evidence struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Item         ResourceRef        `json:"item" bson:"item"`
+	Text         string             `json:"text" bson:"text" validate:"required,max=5000"`
+	Source       string             `json:"source" bson:"source" validate:"required,max=100"` // e.g., "twitter", "instagram", "news"
+	URL          string             `json:"url" bson:"url" validate:"omitempty,url"`
+	Author       string             `json:"author" bson:"author" validate:"max=200"`
+	Sentiment    float64            `json:"sentiment" bson:"sentiment" validate:"min=-1,max=1"` // e.g., -0.68, +0.71
+	PostedAt     *time.Time         `json:"posted_at" bson:"posted_at"`
+	AutoSelected bool               `json:"auto_selected" bson:"auto_selected"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+}
+
+func (e *Evidence) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":            e.ID.Hex(),
+		"item":          e.Item.ToResponse(),
+		"text":          e.Text,
+		"source":        e.Source,
+		"url":           e.URL,
+		"author":        e.Author,
+		"sentiment":     e.Sentiment,
+		"posted_at":     formatOptionalTime(e.PostedAt),
+		"auto_selected": e.AutoSelected,
+		"created_at":    e.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ItemID and ItemVersion identify the stored version of an item of the
+// dashboard resources, for entity tags and for the relations and evidence
+// that point at it.
+
+func (pa *PriorityAction) ItemID() primitive.ObjectID { return pa.ID }
+func (pa *PriorityAction) ItemVersion() int64         { return pa.Version }
+
+func (ds *DashboardStat) ItemID() primitive.ObjectID { return ds.ID }
+func (ds *DashboardStat) ItemVersion() int64         { return ds.Version }
+
+func (s *SentimentTrend) ItemID() primitive.ObjectID { return s.ID }
+func (s *SentimentTrend) ItemVersion() int64         { return s.Version }
+
+func (d *DiscussionTopic) ItemID() primitive.ObjectID { return d.ID }
+func (d *DiscussionTopic) ItemVersion() int64         { return d.Version }
+
+func (c *CompetitiveAnalysis) ItemID() primitive.ObjectID { return c.ID }
+func (c *CompetitiveAnalysis) ItemVersion() int64         { return c.Version }
+
+func (c *ConversationCluster) ItemID() primitive.ObjectID { return c.ID }
+func (c *ConversationCluster) ItemVersion() int64         { return c.Version }
+
+func (r *Risk) ItemID() primitive.ObjectID { return r.ID }
+func (r *Risk) ItemVersion() int64         { return r.Version }
+
+func (o *Opportunity) ItemID() primitive.ObjectID { return o.ID }
+func (o *Opportunity) ItemVersion() int64         { return o.Version }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -35,6 +37,22 @@ type KeyMetric struct {
 	Value string `json:"value" bson:"value"`
 }
 
+// FormatCell renders the metric as "Label: Value" for spreadsheet exports.
+func (m KeyMetric) FormatCell() string {
+	return m.Label + ": " + m.Value
+}
+
+// ParseCell reads a metric written by FormatCell.
+func (m *KeyMetric) ParseCell(text string) error {
+	label, value, ok := strings.Cut(text, ":")
+	if !ok {
+		return fmt.Errorf("key metric %q must look like \"Label: Value\"", text)
+	}
+	m.Label = strings.TrimSpace(label)
+	m.Value = strings.TrimSpace(value)
+	return nil
+}
+
 type Opportunity struct {
 	ID                 primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
 	Title              string               `json:"title" bson:"title" validate:"required,min=3,max=255"`
@@ -48,6 +66,9 @@ type Opportunity struct {
 	RecommendedActions []string             `json:"recommended_actions" bson:"recommended_actions"`
 	IsActive           bool                 `json:"is_active" bson:"is_active"`
 	Order              int                  `json:"order" bson:"order"`
+	FlaggedReason      string               `json:"flagged_reason" bson:"flagged_reason"`
+	FlaggedAt          *time.Time           `json:"flagged_at" bson:"flagged_at"`
+	Version            int64                `json:"version" bson:"version"`
 	CreatedAt          time.Time            `json:"created_at" bson:"created_at"`
 	UpdatedAt          time.Time            `json:"updated_at" bson:"updated_at"`
 }
@@ -66,10 +87,10 @@ func (o *Opportunity) ToResponse() map[string]interface{} {
 		"recommended_actions": o.RecommendedActions,
 		"is_active":           o.IsActive,
 		"order":               o.Order,
+		"flagged_reason":      o.FlaggedReason,
+		"flagged_at":          formatOptionalTime(o.FlaggedAt),
+		"version":             o.Version,
 		"created_at":          o.CreatedAt.Format(time.RFC3339),
 		"updated_at":          o.UpdatedAt.Format(time.RFC3339),
 	}
 }
-
-
-
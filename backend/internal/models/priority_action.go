@@ -60,6 +60,10 @@ type PriorityAction struct {
 	Trend          Trend              `json:"trend" bson:"trend" validate:"required,oneof=increasing decreasing stable"`
 	Icon           string             `json:"icon" bson:"icon" validate:"required"`
 	Status         Status             `json:"status" bson:"status" validate:"omitempty,oneof=not-started in-progress completed"`
+	Order          int                `json:"order" bson:"order"`
+	FlaggedReason  string             `json:"flagged_reason" bson:"flagged_reason"`
+	FlaggedAt      *time.Time         `json:"flagged_at" bson:"flagged_at"`
+	Version        int64              `json:"version" bson:"version"`
 	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
 }
@@ -79,6 +83,10 @@ func (pa *PriorityAction) ToResponse() map[string]interface{} {
 		"trend":          pa.Trend,
 		"icon":           pa.Icon,
 		"status":         pa.Status,
+		"order":          pa.Order,
+		"flagged_reason": pa.FlaggedReason,
+		"flagged_at":     formatOptionalTime(pa.FlaggedAt),
+		"version":        pa.Version,
 		"created_at":     pa.CreatedAt.Format(time.RFC3339),
 		"updated_at":     pa.UpdatedAt.Format(time.RFC3339),
 	}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ResourceType names a resource that can be linked to others
+type ResourceType string
+
+const (
+	ResourceConversationClusters ResourceType = "conversation_clusters"
+	ResourceDiscussionTopics     ResourceType = "discussion_topics"
+	ResourceRisks                ResourceType = "risks"
+	ResourceOpportunities        ResourceType = "opportunities"
+	ResourcePriorityActions      ResourceType = "priority_actions"
+)
+
+// ResourceRef points at one item of a resource
+type ResourceRef struct {
+	Type ResourceType       `json:"type" bson:"type" validate:"required,oneof=conversation_clusters discussion_topics risks opportunities priority_actions"`
+	ID   primitive.ObjectID `json:"id" bson:"id" validate:"required"`
+}
+
+// RelationType says how the From item of a relation affects the To item.
+// It decides what happens to the other item when either one is deleted; the
+// relation itself is always removed with the deleted item:
+//
+//   - drives: To depends on From, e.g. a cluster drives a risk. Deleting From
+//     flags To.
+//   - addresses: From depends on To, e.g. an action addresses a risk. Deleting
+//     To flags From.
+//   - related: no dependency. Neither item is flagged.
+type RelationType string
+
+const (
+	RelationDrives    RelationType = "drives"
+	RelationAddresses RelationType = "addresses"
+	RelationRelated   RelationType = "related"
+)
+
+// Relation is a typed link between two items.
+type Relation struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	From      ResourceRef        `json:"from" bson:"from" validate:"required"`
+	To        ResourceRef        `json:"to" bson:"to" validate:"required"`
+	Type      RelationType       `json:"type" bson:"type" validate:"required,oneof=drives addresses related"`
+	Note      string             `json:"note" bson:"note" validate:"max=500"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// Dependent returns the item that depends on the other end of the relation,
+// and false for a relation without a dependency.
+func (r *Relation) Dependent() (ResourceRef, bool) {
+	switch r.Type {
+	case RelationDrives:
+		return r.To, true
+	case RelationAddresses:
+		return r.From, true
+	}
+	return ResourceRef{}, false
+}
+
+func (r *Relation) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         r.ID.Hex(),
+		"from":       r.From.ToResponse(),
+		"to":         r.To.ToResponse(),
+		"type":       r.Type,
+		"note":       r.Note,
+		"created_at": r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (r ResourceRef) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"type": r.Type,
+		"id":   r.ID.Hex(),
+	}
+}
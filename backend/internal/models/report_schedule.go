@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatPDF  ReportFormat = "pdf"
+	ReportFormatXLSX ReportFormat = "xlsx"
+	ReportFormatJSON ReportFormat = "json"
+)
+
+// ReportSchedule represents a report emailed to a list of recipients on a cron schedule.
+// Resources limits the report to the named dashboard resources; empty includes all of them.
+type ReportSchedule struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name       string             `json:"name" bson:"name" validate:"required,min=3,max=100"`
+	Cron       string             `json:"cron" bson:"cron" validate:"required"` // e.g., "0 8 * * 1" for Mondays at 08:00
+	Timezone   string             `json:"timezone" bson:"timezone"`             // IANA name, defaults to UTC
+	Recipients []string           `json:"recipients" bson:"recipients" validate:"required,min=1,max=50,dive,email"`
+	Format     ReportFormat       `json:"format" bson:"format" validate:"required,oneof=pdf xlsx json"`
+	Period     string             `json:"period" bson:"period"` // e.g., "7d"; empty is the previous month
+	Resources  []string           `json:"resources" bson:"resources" validate:"dive,oneof=dashboard_stats priority_actions risks opportunities sentiment_trends discussion_topics competitive_analyses conversation_clusters"`
+	IsActive   *bool              `json:"is_active" bson:"is_active"` // defaults to true on create, and to the stored value on update
+	NextRunAt  *time.Time         `json:"next_run_at" bson:"next_run_at"`
+	LastRunAt  *time.Time         `json:"last_run_at" bson:"last_run_at"`
+	LastStatus ReportRunStatus    `json:"last_status" bson:"last_status"`
+	Version    int64              `json:"version" bson:"version"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// Active reports whether the schedule fires.
+func (s *ReportSchedule) Active() bool {
+	return s.IsActive != nil && *s.IsActive
+}
+
+func (s *ReportSchedule) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          s.ID.Hex(),
+		"name":        s.Name,
+		"cron":        s.Cron,
+		"timezone":    s.Timezone,
+		"recipients":  s.Recipients,
+		"format":      s.Format,
+		"period":      s.Period,
+		"resources":   s.Resources,
+		"is_active":   s.Active(),
+		"next_run_at": formatOptionalTime(s.NextRunAt),
+		"last_run_at": formatOptionalTime(s.LastRunAt),
+		"last_status": s.LastStatus,
+		"version":     s.Version,
+		"created_at":  s.CreatedAt.Format(time.RFC3339),
+		"updated_at":  s.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type ReportRunStatus string
+
+const (
+	ReportRunRunning   ReportRunStatus = "running"
+	ReportRunSucceeded ReportRunStatus = "succeeded"
+	ReportRunFailed    ReportRunStatus = "failed"
+)
+
+// Trigger says what started a report run or a snapshot
+type Trigger string
+
+const (
+	TriggerSchedule Trigger = "schedule"
+	TriggerManual   Trigger = "manual"
+)
+
+// ReportRun records one execution of a report schedule
+type ReportRun struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	ScheduleID primitive.ObjectID `json:"schedule_id" bson:"schedule_id"`
+	Trigger    Trigger            `json:"trigger" bson:"trigger"`
+	Status     ReportRunStatus    `json:"status" bson:"status"`
+	Format     ReportFormat       `json:"format" bson:"format"`
+	Recipients []string           `json:"recipients" bson:"recipients"`
+	Filename   string             `json:"filename" bson:"filename"`
+	Size       int                `json:"size" bson:"size"` // attachment size in bytes
+	Error      string             `json:"error" bson:"error"`
+	StartedAt  time.Time          `json:"started_at" bson:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at" bson:"finished_at"`
+}
+
+func (r *ReportRun) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"id":          r.ID.Hex(),
+		"schedule_id": r.ScheduleID.Hex(),
+		"trigger":     r.Trigger,
+		"status":      r.Status,
+		"format":      r.Format,
+		"recipients":  r.Recipients,
+		"filename":    r.Filename,
+		"size":        r.Size,
+		"error":       r.Error,
+		"started_at":  r.StartedAt.Format(time.RFC3339),
+		"finished_at": formatOptionalTime(r.FinishedAt),
+	}
+}
+
+// formatOptionalTime formats t as RFC 3339, or nil when unset.
+func formatOptionalTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
@@ -1,6 +1,10 @@
 package models
 
 import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -29,6 +33,34 @@ type RiskIndicator struct {
 	Change float64 `json:"change" bson:"change"`
 }
 
+// FormatCell renders the indicator as "Label: Value (Change)" for
+// spreadsheet exports, e.g. "Negative mentions: 120 (+15)".
+func (i RiskIndicator) FormatCell() string {
+	change := strconv.FormatFloat(i.Change, 'f', -1, 64)
+	if i.Change >= 0 {
+		change = "+" + change
+	}
+	return i.Label + ": " + strconv.FormatFloat(i.Value, 'f', -1, 64) + " (" + change + ")"
+}
+
+// riskIndicatorCell matches "Label: Value" with an optional " (Change)".
+var riskIndicatorCell = regexp.MustCompile(`^(.+):\s*([+-]?[0-9.]+)\s*(?:\(([+-]?[0-9.]+)\))?$`)
+
+// ParseCell reads an indicator written by FormatCell.
+func (i *RiskIndicator) ParseCell(text string) error {
+	match := riskIndicatorCell.FindStringSubmatch(text)
+	if match == nil {
+		return fmt.Errorf("indicator %q must look like \"Label: Value (Change)\"", text)
+	}
+	i.Label = strings.TrimSpace(match[1])
+	i.Value, _ = strconv.ParseFloat(match[2], 64)
+	i.Change = 0
+	if match[3] != "" {
+		i.Change, _ = strconv.ParseFloat(match[3], 64)
+	}
+	return nil
+}
+
 type Risk struct {
 	ID                 primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Title              string             `json:"title" bson:"title" validate:"required,min=3,max=255"`
@@ -41,6 +73,9 @@ type Risk struct {
 	MitigationStrategy []string           `json:"mitigation_strategy" bson:"mitigation_strategy"`
 	IsActive           bool               `json:"is_active" bson:"is_active"`
 	Order              int                `json:"order" bson:"order"`
+	FlaggedReason      string             `json:"flagged_reason" bson:"flagged_reason"`
+	FlaggedAt          *time.Time         `json:"flagged_at" bson:"flagged_at"`
+	Version            int64              `json:"version" bson:"version"`
 	CreatedAt          time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt          time.Time          `json:"updated_at" bson:"updated_at"`
 }
@@ -58,10 +93,10 @@ func (r *Risk) ToResponse() map[string]interface{} {
 		"mitigation_strategy": r.MitigationStrategy,
 		"is_active":           r.IsActive,
 		"order":               r.Order,
+		"flagged_reason":      r.FlaggedReason,
+		"flagged_at":          formatOptionalTime(r.FlaggedAt),
+		"version":             r.Version,
 		"created_at":          r.CreatedAt.Format(time.RFC3339),
 		"updated_at":          r.UpdatedAt.Format(time.RFC3339),
 	}
 }
-
-
-
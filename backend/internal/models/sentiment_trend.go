@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -13,19 +16,51 @@ type SentimentDataPoint struct {
 	Negative float64 `json:"negative" bson:"negative"`
 }
 
+// FormatCell renders the point as "Date: Positive/Negative" for spreadsheet
+// exports, e.g. "2024-05-01: 62/18".
+func (p SentimentDataPoint) FormatCell() string {
+	return p.Date + ": " + strconv.FormatFloat(p.Positive, 'f', -1, 64) + "/" + strconv.FormatFloat(p.Negative, 'f', -1, 64)
+}
+
+// ParseCell reads a data point written by FormatCell.
+func (p *SentimentDataPoint) ParseCell(text string) error {
+	sep := strings.LastIndex(text, ": ")
+	if sep < 0 {
+		sep = strings.LastIndex(text, ":")
+	}
+	if sep < 0 {
+		return fmt.Errorf("data point %q must look like \"Date: Positive/Negative\"", text)
+	}
+	positive, negative, ok := strings.Cut(text[sep+1:], "/")
+	if !ok {
+		return fmt.Errorf("data point %q must look like \"Date: Positive/Negative\"", text)
+	}
+
+	var err error
+	p.Date = strings.TrimSpace(text[:sep])
+	if p.Positive, err = strconv.ParseFloat(strings.TrimSpace(positive), 64); err != nil {
+		return fmt.Errorf("data point %q has an invalid positive value", text)
+	}
+	if p.Negative, err = strconv.ParseFloat(strings.TrimSpace(negative), 64); err != nil {
+		return fmt.Errorf("data point %q has an invalid negative value", text)
+	}
+	return nil
+}
+
 // SentimentTrend represents sentiment analysis data for the dashboard
 type SentimentTrend struct {
-	ID                primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
-	Title             string               `json:"title" bson:"title" validate:"required"`
-	Period            string               `json:"period" bson:"period" validate:"required"` // e.g., "Last 30 days"
-	PositivePercent   float64              `json:"positive_percent" bson:"positive_percent" validate:"required,min=0,max=100"`
-	NegativePercent   float64              `json:"negative_percent" bson:"negative_percent" validate:"required,min=0,max=100"`
-	NeutralPercent    float64              `json:"neutral_percent" bson:"neutral_percent" validate:"required,min=0,max=100"`
-	TrendData         []SentimentDataPoint `json:"trend_data" bson:"trend_data"`
-	IsActive          bool                 `json:"is_active" bson:"is_active"`
-	Order             int                  `json:"order" bson:"order"`
-	CreatedAt         time.Time            `json:"created_at" bson:"created_at"`
-	UpdatedAt         time.Time            `json:"updated_at" bson:"updated_at"`
+	ID              primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Title           string               `json:"title" bson:"title" validate:"required"`
+	Period          string               `json:"period" bson:"period" validate:"required"` // e.g., "Last 30 days"
+	PositivePercent float64              `json:"positive_percent" bson:"positive_percent" validate:"required,min=0,max=100"`
+	NegativePercent float64              `json:"negative_percent" bson:"negative_percent" validate:"required,min=0,max=100"`
+	NeutralPercent  float64              `json:"neutral_percent" bson:"neutral_percent" validate:"required,min=0,max=100"`
+	TrendData       []SentimentDataPoint `json:"trend_data" bson:"trend_data"`
+	IsActive        bool                 `json:"is_active" bson:"is_active"`
+	Order           int                  `json:"order" bson:"order"`
+	Version         int64                `json:"version" bson:"version"`
+	CreatedAt       time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time            `json:"updated_at" bson:"updated_at"`
 }
 
 func (s *SentimentTrend) ToResponse() map[string]interface{} {
@@ -39,8 +74,8 @@ func (s *SentimentTrend) ToResponse() map[string]interface{} {
 		"trend_data":       s.TrendData,
 		"is_active":        s.IsActive,
 		"order":            s.Order,
+		"version":          s.Version,
 		"created_at":       s.CreatedAt.Format(time.RFC3339),
 		"updated_at":       s.UpdatedAt.Format(time.RFC3339),
 	}
 }
-
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Snapshot is a frozen copy of the dashboard's active items on a given date
+type Snapshot struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Date      string             `json:"date" bson:"date"` // YYYY-MM-DD, one snapshot per date
+	Label     string             `json:"label" bson:"label"`
+	Trigger   Trigger            `json:"trigger" bson:"trigger"`
+	Counts    map[string]int     `json:"counts" bson:"counts"` // items per resource
+	Dashboard Dashboard          `json:"dashboard" bson:"dashboard"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// ToSummary describes the snapshot without its items, for listings.
+func (s *Snapshot) ToSummary() map[string]interface{} {
+	return map[string]interface{}{
+		"id":         s.ID.Hex(),
+		"date":       s.Date,
+		"label":      s.Label,
+		"trigger":    s.Trigger,
+		"counts":     s.Counts,
+		"created_at": s.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *Snapshot) ToResponse() map[string]interface{} {
+	response := s.ToSummary()
+	response["dashboard"] = s.Dashboard
+	return response
+}
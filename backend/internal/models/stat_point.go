@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StatPoint is one recorded value in a dashboard stat's time series
+type StatPoint struct {
+	ID     primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	StatID primitive.ObjectID `json:"stat_id" bson:"stat_id"`
+	At     time.Time          `json:"at" bson:"at"`
+	Value  float64            `json:"value" bson:"value"`
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"sort"
+	"time"
+
+	"naradai-backend/internal/models"
+)
+
+const (
+	maxTopics  = 8
+	maxRisks   = 5
+	maxActions = 10
+)
+
+// Executive is the content of the executive report, selected from the
+// dashboard for one period.
+type Executive struct {
+	Period      Period
+	GeneratedAt time.Time
+	Stats       []models.DashboardStat
+	Sentiment   *models.SentimentTrend // first active trend, points limited to the period
+	Topics      []models.DiscussionTopic
+	Competitors []models.CompetitiveAnalysis
+	Risks       []models.Risk
+	Actions     []models.PriorityAction
+
+	include func(resource string) bool
+}
+
+var (
+	riskRank   = map[models.RiskSeverity]int{models.RiskSeverityCritical: 0, models.RiskSeverityHigh: 1, models.RiskSeverityMedium: 2, models.RiskSeverityLow: 3}
+	actionRank = map[models.Priority]int{models.PriorityCritical: 0, models.PriorityHigh: 1, models.PriorityMedium: 2}
+)
+
+// NewExecutive picks the report content from the dashboard: the top topics by
+// volume, competitors by share of voice, the most severe risks and the open
+// priority actions, most urgent first. Sections for resources not named in
+// resources are left out of the report; none means all sections.
+func NewExecutive(d *models.Dashboard, period Period, resources []string) *Executive {
+	e := &Executive{
+		Period:      period,
+		GeneratedAt: d.GeneratedAt,
+		Stats:       d.Stats,
+		include:     includes(resources),
+	}
+
+	if len(d.SentimentTrends) > 0 {
+		trend := d.SentimentTrends[0]
+		trend.TrendData = periodPoints(trend.TrendData, period)
+		e.Sentiment = &trend
+	}
+
+	e.Topics = append([]models.DiscussionTopic(nil), d.DiscussionTopics...)
+	sort.SliceStable(e.Topics, func(i, j int) bool { return e.Topics[i].Volume > e.Topics[j].Volume })
+	if len(e.Topics) > maxTopics {
+		e.Topics = e.Topics[:maxTopics]
+	}
+
+	e.Competitors = append([]models.CompetitiveAnalysis(nil), d.CompetitiveAnalyses...)
+	sort.SliceStable(e.Competitors, func(i, j int) bool { return e.Competitors[i].ShareOfVoice > e.Competitors[j].ShareOfVoice })
+
+	e.Risks = append([]models.Risk(nil), d.Risks...)
+	sort.SliceStable(e.Risks, func(i, j int) bool {
+		if riskRank[e.Risks[i].Severity] != riskRank[e.Risks[j].Severity] {
+			return riskRank[e.Risks[i].Severity] < riskRank[e.Risks[j].Severity]
+		}
+		return e.Risks[i].Probability > e.Risks[j].Probability
+	})
+	if len(e.Risks) > maxRisks {
+		e.Risks = e.Risks[:maxRisks]
+	}
+
+	for _, action := range d.PriorityActions {
+		if action.Status != models.StatusCompleted {
+			e.Actions = append(e.Actions, action)
+		}
+	}
+	sort.SliceStable(e.Actions, func(i, j int) bool {
+		return actionRank[e.Actions[i].Priority] < actionRank[e.Actions[j].Priority]
+	})
+	if len(e.Actions) > maxActions {
+		e.Actions = e.Actions[:maxActions]
+	}
+
+	return e
+}
+
+// periodPoints keeps the trend points dated inside the period. Points whose
+// date cannot be read are kept, since there is no way to place them.
+func periodPoints(points []models.SentimentDataPoint, period Period) []models.SentimentDataPoint {
+	var kept []models.SentimentDataPoint
+	for _, point := range points {
+		if t, ok := period.pointDate(point.Date); ok && !period.Contains(t) {
+			continue
+		}
+		kept = append(kept, point)
+	}
+	return kept
+}
@@ -0,0 +1,119 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/service"
+	"naradai-backend/internal/tabular"
+)
+
+// Attachment is a generated report file.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Generator renders dashboard reports in any of the report formats.
+type Generator struct {
+	dashboard *service.DashboardService
+	brand     Brand
+}
+
+func NewGenerator(dashboard *service.DashboardService, brand Brand) *Generator {
+	return &Generator{dashboard: dashboard, brand: brand}
+}
+
+// Generate builds a report of the named dashboard resources (none means
+// all). period only applies to the PDF executive report.
+func (g *Generator) Generate(ctx context.Context, format models.ReportFormat, period string, resources []string) (*Attachment, error) {
+	dashboard, err := g.dashboard.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	date := dashboard.GeneratedAt.Format("2006-01-02")
+
+	var buf bytes.Buffer
+	switch format {
+	case models.ReportFormatPDF:
+		p, err := ParsePeriod(period, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if err := Render(&buf, NewExecutive(dashboard, p, resources), g.brand); err != nil {
+			return nil, err
+		}
+		return &Attachment{
+			Filename:    fmt.Sprintf("executive-report-%s.pdf", p.Start.Format("2006-01-02")),
+			ContentType: "application/pdf",
+			Data:        buf.Bytes(),
+		}, nil
+
+	case models.ReportFormatXLSX:
+		book, err := Workbook(dashboard, resources)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := book.WriteTo(&buf); err != nil {
+			return nil, err
+		}
+		return &Attachment{
+			Filename:    fmt.Sprintf("dashboard-%s.xlsx", date),
+			ContentType: tabular.XLSX.ContentType(),
+			Data:        buf.Bytes(),
+		}, nil
+
+	case models.ReportFormatJSON:
+		data, err := json.MarshalIndent(filterDashboard(dashboard, resources), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &Attachment{
+			Filename:    fmt.Sprintf("dashboard-%s.json", date),
+			ContentType: "application/json",
+			Data:        data,
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported report format %q", format)
+}
+
+// filterDashboard returns the dashboard as a JSON object holding only the
+// named resources.
+func filterDashboard(d *models.Dashboard, resources []string) map[string]interface{} {
+	include := includes(resources)
+	all := map[string]interface{}{
+		"dashboard_stats":       d.Stats,
+		"priority_actions":      d.PriorityActions,
+		"risks":                 d.Risks,
+		"opportunities":         d.Opportunities,
+		"sentiment_trends":      d.SentimentTrends,
+		"discussion_topics":     d.DiscussionTopics,
+		"competitive_analyses":  d.CompetitiveAnalyses,
+		"conversation_clusters": d.ConversationClusters,
+	}
+	filtered := map[string]interface{}{"generated_at": d.GeneratedAt}
+	for name, items := range all {
+		if include(name) {
+			filtered[name] = items
+		}
+	}
+	return filtered
+}
+
+// includes returns a predicate for the resource filter of a report. Names are
+// the JSON keys of models.Dashboard; an empty filter includes everything.
+func includes(resources []string) func(resource string) bool {
+	if len(resources) == 0 {
+		return func(string) bool { return true }
+	}
+	set := make(map[string]bool, len(resources))
+	for _, name := range resources {
+		set[name] = true
+	}
+	return func(resource string) bool { return set[resource] }
+}
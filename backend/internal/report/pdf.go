@@ -0,0 +1,475 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+	"naradai-backend/internal/models"
+)
+
+// Brand is the styling applied to generated reports.
+type Brand struct {
+	Name     string
+	Color    string // hex, e.g. "#4F46E5"
+	LogoPath string // optional PNG or JPEG printed in the header
+}
+
+type rgb struct{ r, g, b int }
+
+var (
+	textColor     = rgb{31, 41, 55}
+	mutedColor    = rgb{107, 114, 128}
+	lineColor     = rgb{229, 231, 235}
+	positiveColor = rgb{22, 163, 74}
+	negativeColor = rgb{220, 38, 38}
+	severityColor = map[models.RiskSeverity]rgb{
+		models.RiskSeverityCritical: {185, 28, 28},
+		models.RiskSeverityHigh:     {234, 88, 12},
+		models.RiskSeverityMedium:   {202, 138, 4},
+		models.RiskSeverityLow:      {107, 114, 128},
+	}
+	priorityColor = map[models.Priority]rgb{
+		models.PriorityCritical: {185, 28, 28},
+		models.PriorityHigh:     {234, 88, 12},
+		models.PriorityMedium:   {202, 138, 4},
+	}
+)
+
+const (
+	pageMargin   = 15.0
+	contentWidth = 210 - 2*pageMargin
+)
+
+// renderer wraps the document with the report's drawing helpers.
+type renderer struct {
+	pdf   *fpdf.Fpdf
+	tr    func(string) string
+	brand Brand
+	color rgb
+}
+
+// Render writes e as an A4 PDF to w.
+func Render(w io.Writer, e *Executive, brand Brand) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin+5)
+	pdf.SetTitle(brand.Name+" Executive Report - "+e.Period.Label, true)
+	pdf.SetAuthor(brand.Name, true)
+	pdf.SetCreator(brand.Name, true)
+
+	r := &renderer{
+		pdf:   pdf,
+		tr:    pdf.UnicodeTranslatorFromDescriptor(""),
+		brand: brand,
+		color: parseHexColor(brand.Color),
+	}
+	pdf.SetFooterFunc(r.footer)
+
+	pdf.AddPage()
+	r.header(e)
+	if e.include("dashboard_stats") {
+		r.stats(e.Stats)
+	}
+	if e.include("sentiment_trends") {
+		r.sentiment(e.Sentiment)
+	}
+	if e.include("discussion_topics") {
+		r.topics(e.Topics)
+	}
+	if e.include("competitive_analyses") {
+		r.competitors(e.Competitors)
+	}
+	if e.include("risks") {
+		r.risks(e.Risks)
+	}
+	if e.include("priority_actions") {
+		r.actions(e.Actions)
+	}
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+func (r *renderer) header(e *Executive) {
+	pdf := r.pdf
+	r.fill(r.color)
+	pdf.Rect(0, 0, 210, 38, "F")
+
+	x := pageMargin
+	if r.brand.LogoPath != "" {
+		if _, err := os.Stat(r.brand.LogoPath); err == nil {
+			pdf.ImageOptions(r.brand.LogoPath, x, 9, 0, 20, false, fpdf.ImageOptions{ReadDpi: true}, 0, "")
+			x += 28
+		}
+	}
+
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetXY(x, 10)
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.CellFormat(0, 9, r.tr(r.brand.Name+" Executive Report"), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(0, 6, r.tr(e.Period.Label), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.CellFormat(0, 5, "Generated "+e.GeneratedAt.Format("2 January 2006 15:04 MST"), "", 2, "L", false, 0, "")
+
+	pdf.SetY(46)
+}
+
+func (r *renderer) footer() {
+	pdf := r.pdf
+	pdf.SetY(-12)
+	pdf.SetFont("Helvetica", "", 8)
+	r.text(mutedColor)
+	pdf.CellFormat(contentWidth/2, 5, r.tr(r.brand.Name+" - Confidential"), "", 0, "L", false, 0, "")
+	pdf.CellFormat(contentWidth/2, 5, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "R", false, 0, "")
+}
+
+// section starts a titled block, moving to a new page first when less than
+// need millimetres are left.
+func (r *renderer) section(title string, need float64) {
+	pdf := r.pdf
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+need > pageHeight-pageMargin-5 {
+		pdf.AddPage()
+	}
+	pdf.Ln(3)
+	pdf.SetFont("Helvetica", "B", 13)
+	r.text(r.color)
+	pdf.CellFormat(0, 8, r.tr(title), "", 1, "L", false, 0, "")
+	r.draw(r.color)
+	pdf.SetLineWidth(0.5)
+	y := pdf.GetY()
+	pdf.Line(pageMargin, y, pageMargin+contentWidth, y)
+	pdf.SetLineWidth(0.2)
+	pdf.Ln(3)
+}
+
+func (r *renderer) empty(message string) {
+	r.pdf.SetFont("Helvetica", "I", 9)
+	r.text(mutedColor)
+	r.pdf.CellFormat(0, 6, r.tr(message), "", 1, "L", false, 0, "")
+}
+
+func (r *renderer) stats(stats []models.DashboardStat) {
+	r.section("Key Metrics", 30)
+	if len(stats) == 0 {
+		r.empty("No dashboard stats.")
+		return
+	}
+
+	pdf := r.pdf
+	const perRow, gap, height = 4, 4.0, 22.0
+	width := (contentWidth - gap*(perRow-1)) / perRow
+	top := pdf.GetY()
+	for i, stat := range stats {
+		if i > 0 && i%perRow == 0 {
+			top += height + gap
+			if _, pageHeight := pdf.GetPageSize(); top+height > pageHeight-pageMargin-5 {
+				pdf.AddPage()
+				top = pdf.GetY()
+			}
+		}
+		x := pageMargin + float64(i%perRow)*(width+gap)
+
+		r.draw(lineColor)
+		r.fill(rgb{249, 250, 251})
+		pdf.RoundedRect(x, top, width, height, 2, "1234", "FD")
+
+		pdf.SetXY(x+3, top+2.5)
+		pdf.SetFont("Helvetica", "", 8)
+		r.text(mutedColor)
+		pdf.CellFormat(width-6, 4, r.fit(stat.Label, width-6), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 15)
+		r.text(textColor)
+		pdf.CellFormat(width-6, 8, r.fit(stat.Value, width-6), "", 2, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "B", 8)
+		switch stat.Trend {
+		case models.StatTrendDown:
+			r.text(negativeColor)
+		case models.StatTrendFlat:
+			r.text(mutedColor)
+		default:
+			r.text(positiveColor)
+		}
+		pdf.CellFormat(width-6, 4, r.fit(stat.Change, width-6), "", 2, "L", false, 0, "")
+	}
+	pdf.SetY(top + height + 2)
+}
+
+func (r *renderer) sentiment(trend *models.SentimentTrend) {
+	r.section("Sentiment Trend", 80)
+	if trend == nil {
+		r.empty("No sentiment trend.")
+		return
+	}
+
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 10)
+	r.text(textColor)
+	pdf.CellFormat(0, 6, r.tr(trend.Title+" ("+trend.Period+")"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 9)
+	r.text(mutedColor)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Positive %s%%   Negative %s%%   Neutral %s%%",
+		formatNumber(trend.PositivePercent), formatNumber(trend.NegativePercent), formatNumber(trend.NeutralPercent)),
+		"", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	if len(trend.TrendData) == 0 {
+		r.empty("No trend data points in this period.")
+		return
+	}
+	r.lineChart(trend.TrendData, 55)
+}
+
+// lineChart plots positive and negative sentiment (0-100) per data point.
+func (r *renderer) lineChart(points []models.SentimentDataPoint, height float64) {
+	pdf := r.pdf
+	const axisWidth = 10.0
+	left := pageMargin + axisWidth
+	width := contentWidth - axisWidth
+	top := pdf.GetY()
+	bottom := top + height
+
+	pdf.SetFont("Helvetica", "", 7)
+	for _, tick := range []float64{0, 25, 50, 75, 100} {
+		y := bottom - tick/100*height
+		r.draw(lineColor)
+		pdf.Line(left, y, left+width, y)
+		r.text(mutedColor)
+		pdf.SetXY(pageMargin, y-2)
+		pdf.CellFormat(axisWidth-2, 4, strconv.Itoa(int(tick)), "", 0, "R", false, 0, "")
+	}
+
+	step := width
+	if len(points) > 1 {
+		step = width / float64(len(points)-1)
+	}
+	xAt := func(i int) float64 {
+		if len(points) == 1 {
+			return left + width/2
+		}
+		return left + float64(i)*step
+	}
+	yAt := func(v float64) float64 {
+		return bottom - math.Max(0, math.Min(100, v))/100*height
+	}
+
+	series := []struct {
+		color rgb
+		value func(models.SentimentDataPoint) float64
+	}{
+		{positiveColor, func(p models.SentimentDataPoint) float64 { return p.Positive }},
+		{negativeColor, func(p models.SentimentDataPoint) float64 { return p.Negative }},
+	}
+	pdf.SetLineWidth(0.6)
+	for _, s := range series {
+		r.draw(s.color)
+		r.fill(s.color)
+		for i, point := range points {
+			x, y := xAt(i), yAt(s.value(point))
+			if i > 0 {
+				pdf.Line(xAt(i-1), yAt(s.value(points[i-1])), x, y)
+			}
+			pdf.Circle(x, y, 0.8, "F")
+		}
+	}
+	pdf.SetLineWidth(0.2)
+
+	// Label at most ~12 points so the axis stays readable
+	every := (len(points) + 11) / 12
+	r.text(mutedColor)
+	for i, point := range points {
+		if i%every != 0 && i != len(points)-1 {
+			continue
+		}
+		pdf.SetXY(xAt(i)-10, bottom+1)
+		pdf.CellFormat(20, 4, r.tr(point.Date), "", 0, "C", false, 0, "")
+	}
+
+	pdf.SetY(bottom + 7)
+	r.legend([]string{"Positive", "Negative"}, []rgb{positiveColor, negativeColor})
+}
+
+func (r *renderer) legend(labels []string, colors []rgb) {
+	pdf := r.pdf
+	x, y := pageMargin+10, pdf.GetY()
+	pdf.SetFont("Helvetica", "", 8)
+	for i, label := range labels {
+		r.fill(colors[i])
+		pdf.Rect(x, y+1, 3, 3, "F")
+		r.text(textColor)
+		pdf.SetXY(x+4, y)
+		pdf.CellFormat(25, 5, r.tr(label), "", 0, "L", false, 0, "")
+		x += 30
+	}
+	pdf.SetY(y + 7)
+}
+
+func (r *renderer) topics(topics []models.DiscussionTopic) {
+	r.section("Top Discussion Topics", 20+float64(len(topics))*7)
+	if len(topics) == 0 {
+		r.empty("No discussion topics.")
+		return
+	}
+
+	max := 0
+	for _, topic := range topics {
+		if topic.Volume > max {
+			max = topic.Volume
+		}
+	}
+	for _, topic := range topics {
+		color := positiveColor
+		if topic.SentimentScore < 0 {
+			color = negativeColor
+		}
+		r.bar(topic.Name, float64(topic.Volume), float64(max),
+			fmt.Sprintf("%d mentions, sentiment %+.2f", topic.Volume, topic.SentimentScore), color)
+	}
+}
+
+func (r *renderer) competitors(competitors []models.CompetitiveAnalysis) {
+	r.section("Competitive Share of Voice", 20+float64(len(competitors))*7)
+	if len(competitors) == 0 {
+		r.empty("No competitive analysis.")
+		return
+	}
+
+	for i, competitor := range competitors {
+		color := r.color
+		if i > 0 {
+			color = mutedColor
+		}
+		r.bar(competitor.Name, competitor.ShareOfVoice, 100,
+			fmt.Sprintf("%s%% SoV, sentiment %s%%", formatNumber(competitor.ShareOfVoice), formatNumber(competitor.Sentiment)), color)
+	}
+}
+
+// bar draws one labelled horizontal bar scaled against max.
+func (r *renderer) bar(label string, value, max float64, caption string, color rgb) {
+	pdf := r.pdf
+	const labelWidth, captionWidth, height = 45.0, 50.0, 5.0
+	barWidth := contentWidth - labelWidth - captionWidth - 4
+	y := pdf.GetY()
+
+	pdf.SetFont("Helvetica", "", 9)
+	r.text(textColor)
+	pdf.CellFormat(labelWidth, height, r.fit(label, labelWidth-2), "", 0, "L", false, 0, "")
+
+	r.fill(rgb{243, 244, 246})
+	pdf.Rect(pageMargin+labelWidth, y+0.5, barWidth, height-1, "F")
+	if max > 0 && value > 0 {
+		r.fill(color)
+		pdf.Rect(pageMargin+labelWidth, y+0.5, barWidth*math.Min(value/max, 1), height-1, "F")
+	}
+
+	pdf.SetXY(pageMargin+labelWidth+barWidth+4, y)
+	pdf.SetFont("Helvetica", "", 8)
+	r.text(mutedColor)
+	pdf.CellFormat(captionWidth, height, r.tr(caption), "", 1, "L", false, 0, "")
+	pdf.Ln(1.5)
+}
+
+func (r *renderer) risks(risks []models.Risk) {
+	r.section("Top Risks", 40)
+	if len(risks) == 0 {
+		r.empty("No active risks.")
+		return
+	}
+
+	widths := []float64{22, 108, 25, 25}
+	r.tableHeader([]string{"Severity", "Risk", "Probability", "Trend"}, widths)
+	for _, risk := range risks {
+		r.tableRow(widths, severityColor[risk.Severity],
+			strings.ToUpper(string(risk.Severity)), risk.Title, fmt.Sprintf("%d%%", risk.Probability), string(risk.Trend))
+	}
+}
+
+func (r *renderer) actions(actions []models.PriorityAction) {
+	r.section("Open Priority Actions", 40)
+	if len(actions) == 0 {
+		r.empty("No open priority actions.")
+		return
+	}
+
+	widths := []float64{22, 98, 20, 20, 20}
+	r.tableHeader([]string{"Priority", "Action", "Impact", "Effort", "Status"}, widths)
+	for _, action := range actions {
+		status := string(action.Status)
+		if status == "" {
+			status = string(models.StatusNotStarted)
+		}
+		r.tableRow(widths, priorityColor[action.Priority],
+			strings.ToUpper(string(action.Priority)), action.Title, string(action.Impact), string(action.Effort), status)
+	}
+}
+
+func (r *renderer) tableHeader(titles []string, widths []float64) {
+	pdf := r.pdf
+	pdf.SetFont("Helvetica", "B", 8)
+	r.fill(rgb{243, 244, 246})
+	r.text(mutedColor)
+	for i, title := range titles {
+		pdf.CellFormat(widths[i], 7, r.tr(title), "", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// tableRow prints one row; the first cell is a coloured tag and the second
+// is truncated to fit.
+func (r *renderer) tableRow(widths []float64, tag rgb, cells ...string) {
+	pdf := r.pdf
+	r.draw(lineColor)
+	for i, cell := range cells {
+		switch i {
+		case 0:
+			pdf.SetFont("Helvetica", "B", 7)
+			r.text(tag)
+		default:
+			pdf.SetFont("Helvetica", "", 9)
+			r.text(textColor)
+		}
+		pdf.CellFormat(widths[i], 7, r.fit(cell, widths[i]-2), "B", 0, "L", false, 0, "")
+	}
+	pdf.Ln(-1)
+}
+
+// fit translates text for the core fonts and truncates it with an ellipsis
+// so it fits width at the current font.
+func (r *renderer) fit(text string, width float64) string {
+	text = r.tr(text)
+	if r.pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && r.pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return strings.TrimSpace(text) + "..."
+}
+
+func (r *renderer) text(c rgb) { r.pdf.SetTextColor(c.r, c.g, c.b) }
+func (r *renderer) fill(c rgb) { r.pdf.SetFillColor(c.r, c.g, c.b) }
+func (r *renderer) draw(c rgb) { r.pdf.SetDrawColor(c.r, c.g, c.b) }
+
+// parseHexColor reads "#RRGGBB", falling back to indigo.
+func parseHexColor(hex string) rgb {
+	hex = strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(hex) == 6 {
+		if n, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return rgb{int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff)}
+		}
+	}
+	return rgb{79, 70, 229}
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,106 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is the reporting window printed on a report. Sentiment trend points
+// with a recognisable date are limited to the window; everything else on the
+// dashboard is reported as it currently stands.
+type Period struct {
+	Start time.Time // inclusive
+	End   time.Time // exclusive
+	Label string
+}
+
+var (
+	monthPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	quarterPattern = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+	daysPattern    = regexp.MustCompile(`^(\d{1,3})d$`)
+)
+
+// ParsePeriod reads the ?period= parameter of a report:
+//   - "" defaults to the previous calendar month
+//   - "2024-05" is a calendar month
+//   - "2024-Q2" is a calendar quarter
+//   - "30d" is the last 30 days up to now
+func ParsePeriod(value string, now time.Time) (Period, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+		return monthPeriod(start), nil
+	}
+
+	if m := monthPattern.FindStringSubmatch(value); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return Period{}, fmt.Errorf("invalid month in period %q", value)
+		}
+		return monthPeriod(time.Date(year, time.Month(month), 1, 0, 0, 0, 0, now.Location())), nil
+	}
+
+	if m := quarterPattern.FindStringSubmatch(value); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		start := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, now.Location())
+		return Period{
+			Start: start,
+			End:   start.AddDate(0, 3, 0),
+			Label: fmt.Sprintf("Q%d %d", quarter, year),
+		}, nil
+	}
+
+	if m := daysPattern.FindStringSubmatch(value); m != nil {
+		days, _ := strconv.Atoi(m[1])
+		if days < 1 || days > 366 {
+			return Period{}, fmt.Errorf("period %q must be between 1d and 366d", value)
+		}
+		return Period{
+			Start: now.AddDate(0, 0, -days),
+			End:   now,
+			Label: fmt.Sprintf("Last %d days", days),
+		}, nil
+	}
+
+	return Period{}, fmt.Errorf("invalid period %q, use YYYY-MM, YYYY-Qn or Nd (e.g. 30d)", value)
+}
+
+func monthPeriod(start time.Time) Period {
+	return Period{
+		Start: start,
+		End:   start.AddDate(0, 1, 0),
+		Label: start.Format("January 2006"),
+	}
+}
+
+// Contains reports whether t falls inside the period.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// pointDate parses the free-form date of a sentiment trend point. Dates such
+// as "Nov 5" carry no year and are placed in the year of the period, or the
+// year before when that would put them after the period ends.
+func (p Period) pointDate(text string) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, text, p.Start.Location()); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range []string{"Jan 2", "January 2", "2 Jan"} {
+		if t, err := time.ParseInLocation(layout, text, p.Start.Location()); err == nil {
+			t = t.AddDate(p.End.Year(), 0, 0)
+			if !t.Before(p.End) {
+				t = t.AddDate(-1, 0, 0)
+			}
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
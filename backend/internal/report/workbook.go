@@ -0,0 +1,93 @@
+package report
+
+import (
+	"reflect"
+	"time"
+
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/tabular"
+)
+
+// Workbook lays the dashboard out as one sheet per resource, plus a sheet
+// with one row per sentiment trend data point for charting. Only the named
+// resources are included; none means all of them.
+func Workbook(d *models.Dashboard, resources []string) (*tabular.Workbook, error) {
+	include := includes(resources)
+	book := tabular.NewWorkbook()
+	steps := []func() error{
+		func() error { return writeSheet(book, include("dashboard_stats"), "Dashboard Stats", d.Stats) },
+		func() error {
+			return writeSheet(book, include("priority_actions"), "Priority Actions", d.PriorityActions)
+		},
+		func() error { return writeSheet(book, include("risks"), "Risks", d.Risks) },
+		func() error { return writeSheet(book, include("opportunities"), "Opportunities", d.Opportunities) },
+		func() error {
+			return writeSheet(book, include("sentiment_trends"), "Sentiment Trends", d.SentimentTrends)
+		},
+		func() error {
+			if !include("sentiment_trends") {
+				return nil
+			}
+			sheet, err := book.AddSheet("Sentiment Trend Data")
+			if err != nil {
+				return err
+			}
+			if err := sheet.WriteHeader([]string{"trend_id", "trend_title", "date", "positive", "negative"}); err != nil {
+				return err
+			}
+			for _, trend := range d.SentimentTrends {
+				for _, point := range trend.TrendData {
+					if err := sheet.WriteRow([]interface{}{trend.ID.Hex(), trend.Title, point.Date, point.Positive, point.Negative}); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+		func() error {
+			return writeSheet(book, include("discussion_topics"), "Discussion Topics", d.DiscussionTopics)
+		},
+		func() error {
+			return writeSheet(book, include("competitive_analyses"), "Competitive Analyses", d.CompetitiveAnalyses)
+		},
+		func() error {
+			return writeSheet(book, include("conversation_clusters"), "Conversation Clusters", d.ConversationClusters)
+		},
+		func() error {
+			sheet, err := book.AddSheet("About")
+			if err != nil {
+				return err
+			}
+			if err := sheet.WriteHeader([]string{"generated_at"}); err != nil {
+				return err
+			}
+			return sheet.WriteRow([]interface{}{d.GeneratedAt.Format(time.RFC3339)})
+		},
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+	return book, nil
+}
+
+func writeSheet[T any](book *tabular.Workbook, include bool, title string, items []T) error {
+	if !include {
+		return nil
+	}
+	sheet, err := book.AddSheet(title)
+	if err != nil {
+		return err
+	}
+	columns := tabular.Columns(reflect.TypeOf((*T)(nil)))
+	if err := sheet.WriteHeader(tabular.Names(columns)); err != nil {
+		return err
+	}
+	for i := range items {
+		if err := sheet.WriteRow(tabular.Values(columns, &items[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"naradai-backend/internal/models"
+)
+
+type EvidenceRepository struct {
+	collection *mongo.Collection
+}
+
+func NewEvidenceRepository(db *mongo.Database) *EvidenceRepository {
+	return &EvidenceRepository{
+		collection: db.Collection("evidence"),
+	}
+}
+
+// EnsureIndexes indexes evidence by the item it belongs to.
+func (r *EvidenceRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "item.type", Value: 1}, {Key: "item.id", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return err
+}
+
+func itemEvidenceFilter(item models.ResourceRef) bson.M {
+	return bson.M{"item.type": item.Type, "item.id": item.ID}
+}
+
+// Create inserts the evidence; several at once share one creation time.
+func (r *EvidenceRepository) Create(ctx context.Context, evidence ...*models.Evidence) error {
+	if len(evidence) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, len(evidence))
+	for i, e := range evidence {
+		e.ID = primitive.NewObjectID()
+		e.CreatedAt = now
+		documents[i] = e
+	}
+	_, err := r.collection.InsertMany(ctx, documents)
+	return err
+}
+
+// GetByItem returns the evidence of an item in the order it was added.
+func (r *EvidenceRepository) GetByItem(ctx context.Context, item models.ResourceRef) ([]models.Evidence, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, itemEvidenceFilter(item), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	evidence := []models.Evidence{}
+	if err = cursor.All(ctx, &evidence); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+func (r *EvidenceRepository) CountByItem(ctx context.Context, item models.ResourceRef) (int64, error) {
+	return r.collection.CountDocuments(ctx, itemEvidenceFilter(item))
+}
+
+// Delete removes one piece of evidence from the item.
+func (r *EvidenceRepository) Delete(ctx context.Context, item models.ResourceRef, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := itemEvidenceFilter(item)
+	filter["_id"] = objectID
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteAutoSelected removes the evidence of an item that the server picked,
+// keeping what was added by hand.
+func (r *EvidenceRepository) DeleteAutoSelected(ctx context.Context, item models.ResourceRef) error {
+	filter := itemEvidenceFilter(item)
+	filter["auto_selected"] = true
+	_, err := r.collection.DeleteMany(ctx, filter)
+	return err
+}
+
+// DeleteByItem removes all evidence of an item.
+func (r *EvidenceRepository) DeleteByItem(ctx context.Context, item models.ResourceRef) error {
+	_, err := r.collection.DeleteMany(ctx, itemEvidenceFilter(item))
+	return err
+}
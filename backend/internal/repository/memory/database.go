@@ -0,0 +1,381 @@
+// Package memory implements the repository stores in memory, for tests and
+// local experiments without a MongoDB server.
+//
+// Documents are kept as BSON-encoded maps, so struct tags, omitempty and
+// type conversions behave as they do against MongoDB. Filters support the
+// equality and comparison operators the repositories and handlers use
+// ($eq, $ne, $in, $nin, $gt, $gte, $lt, $lte, $exists, $and, $or, $nor) on
+// dotted paths, and sorting follows MongoDB's ordering of BSON types.
+// Anything else is reported as an error rather than silently ignored.
+package memory
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// Database is a set of named in-memory collections, the counterpart of a
+// *mongo.Database. It is safe for concurrent use.
+type Database struct {
+	mu          sync.Mutex
+	collections map[string]*collection
+}
+
+func NewDatabase() *Database {
+	return &Database{collections: make(map[string]*collection)}
+}
+
+func (db *Database) collection(name string) *collection {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	c, ok := db.collections[name]
+	if !ok {
+		c = &collection{name: name}
+		db.collections[name] = c
+	}
+	return c
+}
+
+// collection holds documents in insertion order, which is the natural order
+// MongoDB returns unsorted results in for a fresh collection.
+type collection struct {
+	name   string
+	mu     sync.RWMutex
+	docs   []bson.M
+	unique [][]string
+}
+
+// ensureUnique declares a unique index over the fields; _id is always unique.
+func (c *collection) ensureUnique(fields ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, index := range c.unique {
+		if strings.Join(index, ",") == strings.Join(fields, ",") {
+			return
+		}
+	}
+	c.unique = append(c.unique, fields)
+}
+
+func (c *collection) insert(values ...interface{}) error {
+	docs := make([]bson.M, len(values))
+	for i, value := range values {
+		doc, err := toDoc(value)
+		if err != nil {
+			return err
+		}
+		if _, ok := doc["_id"]; !ok {
+			doc["_id"] = primitive.NewObjectID()
+		}
+		docs[i] = doc
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, doc := range docs {
+		if err := c.checkUnique(doc, -1, docs[:i]); err != nil {
+			return err
+		}
+	}
+	c.docs = append(c.docs, docs...)
+	return nil
+}
+
+// find returns copies of the matching documents in sort order, skipping the
+// first skip and returning at most limit of them; a limit of 0 is no limit.
+func (c *collection) find(filter interface{}, sort bson.D, skip, limit int64) ([]bson.M, error) {
+	query, err := toDoc(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var found []bson.M
+	for _, doc := range c.docs {
+		ok, err := matches(doc, query)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, doc)
+		}
+	}
+	sortDocs(found, sort)
+
+	if skip >= int64(len(found)) {
+		return nil, nil
+	}
+	found = found[skip:]
+	if limit > 0 && limit < int64(len(found)) {
+		found = found[:limit]
+	}
+	copies := make([]bson.M, len(found))
+	for i, doc := range found {
+		if copies[i], err = toDoc(doc); err != nil {
+			return nil, err
+		}
+	}
+	return copies, nil
+}
+
+func (c *collection) count(filter interface{}) (int64, error) {
+	query, err := toDoc(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var n int64
+	for _, doc := range c.docs {
+		ok, err := matches(doc, query)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// update applies the update operators to the matching documents, to the
+// first one only unless many is set. With upsert, a document built from the
+// filter's equality conditions is inserted when nothing matches. It returns
+// the number of documents matched.
+func (c *collection) update(filter, update interface{}, many, upsert bool) (int64, error) {
+	query, err := toDoc(filter)
+	if err != nil {
+		return 0, err
+	}
+	operators, err := toDoc(update)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var matched int64
+	for i, doc := range c.docs {
+		ok, err := matches(doc, query)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		updated, err := toDoc(doc)
+		if err != nil {
+			return 0, err
+		}
+		if err := apply(updated, operators, false); err != nil {
+			return 0, err
+		}
+		if err := c.checkUnique(updated, i, nil); err != nil {
+			return 0, err
+		}
+		c.docs[i] = updated
+		matched++
+		if !many {
+			break
+		}
+	}
+	if matched > 0 || !upsert {
+		return matched, nil
+	}
+
+	doc := bson.M{}
+	for key, value := range query {
+		if !strings.HasPrefix(key, "$") && !isOperator(value) {
+			if err := setPath(doc, key, value); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if err := apply(doc, operators, true); err != nil {
+		return 0, err
+	}
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	if err := c.checkUnique(doc, -1, nil); err != nil {
+		return 0, err
+	}
+	c.docs = append(c.docs, doc)
+	return 0, nil
+}
+
+// replace swaps the first matching document for value, keeping its _id, or
+// inserts value when nothing matches and upsert is set.
+func (c *collection) replace(filter, value interface{}, upsert bool) (int64, error) {
+	query, err := toDoc(filter)
+	if err != nil {
+		return 0, err
+	}
+	doc, err := toDoc(value)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, existing := range c.docs {
+		ok, err := matches(existing, query)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			continue
+		}
+		doc["_id"] = existing["_id"]
+		if err := c.checkUnique(doc, i, nil); err != nil {
+			return 0, err
+		}
+		c.docs[i] = doc
+		return 1, nil
+	}
+	if !upsert {
+		return 0, nil
+	}
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+	if err := c.checkUnique(doc, -1, nil); err != nil {
+		return 0, err
+	}
+	c.docs = append(c.docs, doc)
+	return 0, nil
+}
+
+// delete removes the matching documents, the first one only unless many is
+// set, and returns how many it removed.
+func (c *collection) delete(filter interface{}, many bool) (int64, error) {
+	query, err := toDoc(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := c.docs[:0]
+	var deleted int64
+	for _, doc := range c.docs {
+		ok, err := matches(doc, query)
+		if err != nil {
+			return 0, err
+		}
+		if ok && (many || deleted == 0) {
+			deleted++
+			continue
+		}
+		kept = append(kept, doc)
+	}
+	c.docs = kept
+	return deleted, nil
+}
+
+// checkUnique reports a duplicate key error when doc collides with a stored
+// document other than the one at index self, or with one of pending.
+func (c *collection) checkUnique(doc bson.M, self int, pending []bson.M) error {
+	indexes := append([][]string{{"_id"}}, c.unique...)
+	for _, fields := range indexes {
+		key := indexKey(doc, fields)
+		for i, other := range c.docs {
+			if i != self && compare(key, indexKey(other, fields)) == 0 {
+				return duplicateKey(c.name, fields)
+			}
+		}
+		for _, other := range pending {
+			if compare(key, indexKey(other, fields)) == 0 {
+				return duplicateKey(c.name, fields)
+			}
+		}
+	}
+	return nil
+}
+
+func indexKey(doc bson.M, fields []string) bson.A {
+	key := make(bson.A, len(fields))
+	for i, field := range fields {
+		key[i], _ = lookup(doc, field)
+	}
+	return key
+}
+
+// duplicateKey is the error MongoDB returns for a unique index violation,
+// so mongo.IsDuplicateKeyError recognises it.
+func duplicateKey(collection string, fields []string) error {
+	return mongo.WriteException{
+		WriteErrors: mongo.WriteErrors{{
+			Code:    11000,
+			Message: fmt.Sprintf("E11000 duplicate key error collection: %s index: %s", collection, strings.Join(fields, "_1_")+"_1"),
+		}},
+	}
+}
+
+// toDoc converts a struct, map or bson.D to a document by encoding it as
+// BSON, which also makes a deep copy. A nil value is an empty document.
+func toDoc(value interface{}) (bson.M, error) {
+	if value == nil {
+		return bson.M{}, nil
+	}
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.M{}
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// fromDoc decodes a document into out, which must point to a zero value.
+func fromDoc(doc bson.M, out interface{}) error {
+	data, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, out)
+}
+
+// decodeAll decodes documents into a slice of T.
+func decodeAll[T any](docs []bson.M) ([]T, error) {
+	if len(docs) == 0 {
+		return nil, nil
+	}
+	items := make([]T, len(docs))
+	for i, doc := range docs {
+		if err := fromDoc(doc, &items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
+
+var (
+	_ repository.OrderedStore[models.Risk] = (*ResourceRepository[models.Risk, *models.Risk])(nil)
+	_ repository.StatPointStore            = (*StatPointRepository)(nil)
+	_ repository.StatMetricStore           = (*StatMetricRepository)(nil)
+	_ repository.RelationStore             = (*RelationRepository)(nil)
+	_ repository.EvidenceStore             = (*EvidenceRepository)(nil)
+	_ repository.SnapshotStore             = (*SnapshotRepository)(nil)
+	_ repository.ReportScheduleStore       = (*ReportScheduleRepository)(nil)
+	_ repository.ReportRunStore            = (*ReportRunRepository)(nil)
+)
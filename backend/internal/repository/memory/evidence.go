@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+)
+
+type EvidenceRepository struct {
+	collection *collection
+}
+
+func NewEvidenceRepository(db *Database) *EvidenceRepository {
+	return &EvidenceRepository{collection: db.collection("evidence")}
+}
+
+func itemEvidenceFilter(item models.ResourceRef) bson.M {
+	return bson.M{"item.type": item.Type, "item.id": item.ID}
+}
+
+// Create inserts the evidence; several at once share one creation time.
+func (r *EvidenceRepository) Create(ctx context.Context, evidence ...*models.Evidence) error {
+	if len(evidence) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	documents := make([]interface{}, len(evidence))
+	for i, e := range evidence {
+		e.ID = primitive.NewObjectID()
+		e.CreatedAt = now
+		documents[i] = e
+	}
+	return r.collection.insert(documents...)
+}
+
+// GetByItem returns the evidence of an item in the order it was added.
+func (r *EvidenceRepository) GetByItem(ctx context.Context, item models.ResourceRef) ([]models.Evidence, error) {
+	docs, err := r.collection.find(itemEvidenceFilter(item), bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	evidence, err := decodeAll[models.Evidence](docs)
+	if evidence == nil && err == nil {
+		evidence = []models.Evidence{}
+	}
+	return evidence, err
+}
+
+func (r *EvidenceRepository) CountByItem(ctx context.Context, item models.ResourceRef) (int64, error) {
+	return r.collection.count(itemEvidenceFilter(item))
+}
+
+// Delete removes one piece of evidence from the item.
+func (r *EvidenceRepository) Delete(ctx context.Context, item models.ResourceRef, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	filter := itemEvidenceFilter(item)
+	filter["_id"] = objectID
+	deleted, err := r.collection.delete(filter, false)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteAutoSelected removes the evidence of an item that the server picked,
+// keeping what was added by hand.
+func (r *EvidenceRepository) DeleteAutoSelected(ctx context.Context, item models.ResourceRef) error {
+	filter := itemEvidenceFilter(item)
+	filter["auto_selected"] = true
+	_, err := r.collection.delete(filter, true)
+	return err
+}
+
+// DeleteByItem removes all evidence of an item.
+func (r *EvidenceRepository) DeleteByItem(ctx context.Context, item models.ResourceRef) error {
+	_, err := r.collection.delete(itemEvidenceFilter(item), true)
+	return err
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/idempotency"
+)
+
+// IdempotencyStore keeps idempotency records, the counterpart of
+// idempotency.MongoStore. Records do not expire.
+type IdempotencyStore struct {
+	collection *collection
+}
+
+func NewIdempotencyStore(db *Database) *IdempotencyStore {
+	return &IdempotencyStore{collection: db.collection("idempotency_keys")}
+}
+
+func (s *IdempotencyStore) Reserve(ctx context.Context, key, requestHash, owner string) (*idempotency.Record, bool, error) {
+	now := time.Now()
+	record := &idempotency.Record{
+		Key:         key,
+		RequestHash: requestHash,
+		State:       idempotency.StateProcessing,
+		Owner:       owner,
+		CreatedAt:   now,
+	}
+
+	err := s.collection.insert(record)
+	if err == nil {
+		return record, true, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, false, err
+	}
+
+	existing, err := s.get(key)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return s.Reserve(ctx, key, requestHash, owner)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return existing, false, nil
+}
+
+func (s *IdempotencyStore) TakeOver(ctx context.Context, key, requestHash, owner string, staleBefore time.Time) (*idempotency.Record, bool, error) {
+	matched, err := s.collection.update(bson.M{
+		"_id":          key,
+		"state":        idempotency.StateProcessing,
+		"request_hash": requestHash,
+		"created_at":   bson.M{"$lt": staleBefore},
+	}, bson.M{
+		"$set": bson.M{"owner": owner, "created_at": time.Now()},
+	}, false, false)
+	if err != nil {
+		return nil, false, err
+	}
+	if matched == 0 {
+		return s.Reserve(ctx, key, requestHash, owner)
+	}
+	record, err := s.get(key)
+	if err != nil {
+		return nil, false, err
+	}
+	return record, true, nil
+}
+
+func (s *IdempotencyStore) Complete(ctx context.Context, key, owner string, statusCode int, header http.Header, body []byte) error {
+	matched, err := s.collection.update(bson.M{"_id": key, "owner": owner, "state": idempotency.StateProcessing}, bson.M{
+		"$set": bson.M{
+			"state":       idempotency.StateCompleted,
+			"status_code": statusCode,
+			"headers":     idempotency.ReplayedHeaders(header),
+			"body":        body,
+		},
+		"$unset": bson.M{"owner": ""},
+	}, false, false)
+	if err != nil {
+		return err
+	}
+	if matched == 0 {
+		return idempotency.ErrNotReserved
+	}
+	return nil
+}
+
+func (s *IdempotencyStore) Release(ctx context.Context, key, owner string) error {
+	_, err := s.collection.delete(bson.M{"_id": key, "owner": owner, "state": idempotency.StateProcessing}, false)
+	return err
+}
+
+func (s *IdempotencyStore) get(key string) (*idempotency.Record, error) {
+	docs, err := s.collection.find(bson.M{"_id": key}, nil, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	var record idempotency.Record
+	if err := fromDoc(docs[0], &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+var _ idempotency.Store = (*IdempotencyStore)(nil)
@@ -0,0 +1,263 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/repository"
+)
+
+// items stores the items of an API resource the way the MongoDB repositories do:
+// Create sets the ID, version 1 and both timestamps, Update overwrites every
+// field except the ones the server owns, and writes check the version.
+type items[T any] struct {
+	collection *collection
+	sort       bson.D
+	// owned are the fields besides _id, created_at and version that Update
+	// leaves alone
+	owned []string
+	// prepare fills in defaults before the item is written
+	prepare func(item *T, creating bool)
+	// active items are created with is_active set
+	active bool
+	// linked items are created without a flag
+	linked bool
+}
+
+func (r *items[T]) Create(ctx context.Context, item *T) error {
+	if r.prepare != nil {
+		r.prepare(item, true)
+	}
+	doc, err := toDoc(item)
+	if err != nil {
+		return err
+	}
+	now := primitive.NewDateTimeFromTime(time.Now())
+	doc["_id"] = primitive.NewObjectID()
+	doc["version"] = int64(1)
+	doc["created_at"] = now
+	doc["updated_at"] = now
+	if r.active {
+		doc["is_active"] = true
+	}
+	if r.linked {
+		doc["flagged_reason"] = ""
+		doc["flagged_at"] = nil
+	}
+
+	if err := r.collection.insert(doc); err != nil {
+		return err
+	}
+	return reload(doc, item)
+}
+
+func (r *items[T]) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]T, int64, error) {
+	total, err := r.collection.count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	docs, err := r.collection.find(filter, r.sort, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	items, err := decodeAll[T](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// Each calls fn for every item matching filter in list order, on a copy of
+// the items taken when it starts.
+func (r *items[T]) Each(ctx context.Context, filter bson.M, fn func(*T) error) error {
+	docs, err := r.collection.find(filter, r.sort, 0, 0)
+	if err != nil {
+		return err
+	}
+	for _, doc := range docs {
+		var item T
+		if err := fromDoc(doc, &item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *items[T]) GetByID(ctx context.Context, id string) (*T, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return findOne[T](r.collection, bson.M{"_id": objectID})
+}
+
+func (r *items[T]) Update(ctx context.Context, id string, item *T, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	if r.prepare != nil {
+		r.prepare(item, false)
+	}
+	doc, err := toDoc(item)
+	if err != nil {
+		return err
+	}
+	doc["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
+	if err := reload(doc, item); err != nil {
+		return err
+	}
+	for _, field := range append([]string{"_id", "created_at", "version"}, r.owned...) {
+		delete(doc, field)
+	}
+
+	return r.write(objectID, version, func(filter bson.M) (int64, error) {
+		return r.collection.update(filter, bson.M{"$set": doc, "$inc": bson.M{"version": 1}}, false, false)
+	})
+}
+
+// Patch sets only the given fields on the item and bumps its version.
+func (r *items[T]) Patch(ctx context.Context, id string, fields bson.M, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	fields["updated_at"] = time.Now()
+	return r.write(objectID, version, func(filter bson.M) (int64, error) {
+		return r.collection.update(filter, bson.M{"$set": fields, "$inc": bson.M{"version": 1}}, false, false)
+	})
+}
+
+func (r *items[T]) Delete(ctx context.Context, id string, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	return r.write(objectID, version, func(filter bson.M) (int64, error) {
+		return r.collection.delete(filter, false)
+	})
+}
+
+// write runs a conditional write on the item and turns a write that matched
+// nothing into mongo.ErrNoDocuments or repository.ErrVersionConflict.
+func (r *items[T]) write(objectID primitive.ObjectID, version int64, fn func(filter bson.M) (int64, error)) error {
+	filter := bson.M{"_id": objectID}
+	switch {
+	case version == repository.AnyVersion:
+	case version == 0:
+		// Documents written before versioning was introduced have no version field
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	default:
+		filter["version"] = version
+	}
+
+	matched, err := fn(filter)
+	if err != nil || matched > 0 {
+		return err
+	}
+	if version == repository.AnyVersion {
+		return mongo.ErrNoDocuments
+	}
+	count, err := r.collection.count(bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return repository.ErrVersionConflict
+}
+
+// ordered are items the dashboard shows in a user-defined order.
+type ordered[T any] struct {
+	items[T]
+}
+
+// Reorder rewrites order to follow the given sequence of IDs. The whole list
+// is checked first, so either every item moves or none does.
+func (r *ordered[T]) Reorder(ctx context.Context, ids []string) error {
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	for i, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a valid id", repository.ErrInvalidReorder, id)
+		}
+		if seen[objectID] {
+			return fmt.Errorf("%w: %q is listed more than once", repository.ErrInvalidReorder, id)
+		}
+		seen[objectID] = true
+		objectIDs[i] = objectID
+	}
+
+	c := r.collection
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	positions := make(map[primitive.ObjectID]int, len(c.docs))
+	for i, doc := range c.docs {
+		if id, ok := doc["_id"].(primitive.ObjectID); ok {
+			positions[id] = i
+		}
+	}
+	var missing int
+	for _, objectID := range objectIDs {
+		if _, ok := positions[objectID]; !ok {
+			missing++
+		}
+	}
+	if missing > 0 {
+		return fmt.Errorf("%w: %d of %d ids do not exist", repository.ErrInvalidReorder, missing, len(ids))
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	for i, objectID := range objectIDs {
+		doc := c.docs[positions[objectID]]
+		version, err := add(doc["version"], int64(1))
+		if err != nil {
+			return err
+		}
+		doc["order"] = int64(i)
+		doc["updated_at"] = now
+		doc["version"] = version
+	}
+	return nil
+}
+
+// findOne decodes the first document matching filter, or returns
+// mongo.ErrNoDocuments.
+func findOne[T any](c *collection, filter bson.M) (*T, error) {
+	docs, err := c.find(filter, nil, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) == 0 {
+		return nil, mongo.ErrNoDocuments
+	}
+	var item T
+	if err := fromDoc(docs[0], &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// reload replaces *item with doc decoded, so the caller sees the fields the
+// store filled in.
+func reload[T any](doc bson.M, item *T) error {
+	var stored T
+	if err := fromDoc(doc, &stored); err != nil {
+		return err
+	}
+	*item = stored
+	return nil
+}
@@ -0,0 +1,417 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// matches reports whether doc satisfies the query filter.
+func matches(doc, query bson.M) (bool, error) {
+	for key, condition := range query {
+		var ok bool
+		var err error
+		switch key {
+		case "$and", "$or", "$nor":
+			ok, err = matchLogical(doc, key, condition)
+		default:
+			if strings.HasPrefix(key, "$") {
+				return false, fmt.Errorf("memory: unsupported query operator %s", key)
+			}
+			value, found := lookup(doc, key)
+			ok, err = matchField(value, found, condition)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchLogical(doc bson.M, operator string, condition interface{}) (bool, error) {
+	clauses, ok := condition.(bson.A)
+	if !ok || len(clauses) == 0 {
+		return false, fmt.Errorf("memory: %s needs a non-empty array", operator)
+	}
+	for _, clause := range clauses {
+		query, ok := asDoc(clause)
+		if !ok {
+			return false, fmt.Errorf("memory: %s clauses must be documents", operator)
+		}
+		ok, err := matches(doc, query)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case operator == "$and" && !ok:
+			return false, nil
+		case operator == "$or" && ok:
+			return true, nil
+		case operator == "$nor" && ok:
+			return false, nil
+		}
+	}
+	return operator != "$or", nil
+}
+
+// matchField evaluates the condition on one field: a value to equal or a
+// document of operators.
+func matchField(value interface{}, found bool, condition interface{}) (bool, error) {
+	if !isOperator(condition) {
+		return equals(value, condition), nil
+	}
+	operators, _ := asDoc(condition)
+	for operator, operand := range operators {
+		var ok bool
+		switch operator {
+		case "$eq":
+			ok = equals(value, operand)
+		case "$ne":
+			ok = !equals(value, operand)
+		case "$in", "$nin":
+			list, isList := operand.(bson.A)
+			if !isList {
+				return false, fmt.Errorf("memory: %s needs an array", operator)
+			}
+			for _, want := range list {
+				if equals(value, want) {
+					ok = true
+					break
+				}
+			}
+			if operator == "$nin" {
+				ok = !ok
+			}
+		case "$gt", "$gte", "$lt", "$lte":
+			ok = found && anyElement(value, func(v interface{}) bool {
+				if typeOrder(v) != typeOrder(operand) {
+					return false
+				}
+				c := compare(v, operand)
+				switch operator {
+				case "$gt":
+					return c > 0
+				case "$gte":
+					return c >= 0
+				case "$lt":
+					return c < 0
+				default:
+					return c <= 0
+				}
+			})
+		case "$exists":
+			want, _ := operand.(bool)
+			ok = found == want
+		default:
+			return false, fmt.Errorf("memory: unsupported query operator %s", operator)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// equals matches like a MongoDB equality condition: null matches a missing
+// field, and an array matches if it or any of its elements is equal.
+func equals(value, want interface{}) bool {
+	if compare(value, want) == 0 && typeOrder(value) == typeOrder(want) {
+		return true
+	}
+	if list, ok := value.(bson.A); ok {
+		for _, element := range list {
+			if compare(element, want) == 0 && typeOrder(element) == typeOrder(want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anyElement(value interface{}, fn func(interface{}) bool) bool {
+	if list, ok := value.(bson.A); ok {
+		for _, element := range list {
+			if fn(element) {
+				return true
+			}
+		}
+		return false
+	}
+	return fn(value)
+}
+
+// isOperator reports whether value is a document of query operators rather
+// than a value to compare with.
+func isOperator(value interface{}) bool {
+	doc, ok := asDoc(value)
+	if !ok || len(doc) == 0 {
+		return false
+	}
+	for key := range doc {
+		if !strings.HasPrefix(key, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+func asDoc(value interface{}) (bson.M, bool) {
+	switch v := value.(type) {
+	case bson.M:
+		return v, true
+	case bson.D:
+		return v.Map(), true
+	}
+	return nil, false
+}
+
+// lookup returns the value at a dotted path, descending into embedded
+// documents and array indexes.
+func lookup(doc bson.M, path string) (interface{}, bool) {
+	var value interface{} = doc
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case bson.M:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case bson.A:
+			var index int
+			if _, err := fmt.Sscan(key, &index); err != nil || index < 0 || index >= len(v) {
+				return nil, false
+			}
+			value = v[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// setPath sets the value at a dotted path, creating embedded documents on
+// the way.
+func setPath(doc bson.M, path string, value interface{}) error {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := doc[key]
+		if !ok || next == nil {
+			child := bson.M{}
+			doc[key] = child
+			doc = child
+			continue
+		}
+		child, ok := next.(bson.M)
+		if !ok {
+			return fmt.Errorf("memory: cannot set %s: %s is not a document", path, key)
+		}
+		doc = child
+	}
+	doc[keys[len(keys)-1]] = value
+	return nil
+}
+
+func unsetPath(doc bson.M, path string) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, ok := doc[key].(bson.M)
+		if !ok {
+			return
+		}
+		doc = child
+	}
+	delete(doc, keys[len(keys)-1])
+}
+
+// apply runs the update operators on doc. $setOnInsert only applies when
+// inserting is set.
+func apply(doc, update bson.M, inserting bool) error {
+	for operator, operand := range update {
+		fields, ok := asDoc(operand)
+		if !ok {
+			return fmt.Errorf("memory: %s needs a document", operator)
+		}
+		for path, value := range fields {
+			var err error
+			switch operator {
+			case "$set":
+				err = setPath(doc, path, value)
+			case "$setOnInsert":
+				if inserting {
+					err = setPath(doc, path, value)
+				}
+			case "$unset":
+				unsetPath(doc, path)
+			case "$inc":
+				current, _ := lookup(doc, path)
+				var sum interface{}
+				sum, err = add(current, value)
+				if err == nil {
+					err = setPath(doc, path, sum)
+				}
+			default:
+				return fmt.Errorf("memory: unsupported update operator %s", operator)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// add increments a number, keeping integers integral.
+func add(current, increment interface{}) (interface{}, error) {
+	if current == nil {
+		current = int32(0)
+	}
+	a, aInt, ok := number(current)
+	b, bInt, ok2 := number(increment)
+	if !ok || !ok2 {
+		return nil, fmt.Errorf("memory: cannot increment %v by %v", current, increment)
+	}
+	if aInt && bInt {
+		return int64(a) + int64(b), nil
+	}
+	return a + b, nil
+}
+
+func number(value interface{}) (float64, bool, bool) {
+	switch v := value.(type) {
+	case int32:
+		return float64(v), true, true
+	case int64:
+		return float64(v), true, true
+	case int:
+		return float64(v), true, true
+	case float64:
+		return v, false, true
+	}
+	return 0, false, false
+}
+
+// sortDocs sorts documents stably by the sort keys, 1 ascending and -1
+// descending, treating missing fields as null.
+func sortDocs(docs []bson.M, keys bson.D) {
+	if len(keys) == 0 {
+		return
+	}
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, key := range keys {
+			a, _ := lookup(docs[i], key.Key)
+			b, _ := lookup(docs[j], key.Key)
+			c := compare(a, b)
+			if direction, _, _ := number(key.Value); direction < 0 {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// typeOrder ranks BSON types the way MongoDB compares values of different
+// types.
+func typeOrder(value interface{}) int {
+	switch value.(type) {
+	case nil, primitive.Undefined:
+		return 1
+	case int32, int64, int, float64, primitive.Decimal128:
+		return 2
+	case string, primitive.Symbol:
+		return 3
+	case bson.M, bson.D:
+		return 4
+	case bson.A:
+		return 5
+	case primitive.Binary:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	case primitive.Timestamp:
+		return 10
+	case primitive.Regex:
+		return 11
+	}
+	return 12
+}
+
+// compare orders two BSON values, first by type and then by value.
+func compare(a, b interface{}) int {
+	ta, tb := typeOrder(a), typeOrder(b)
+	if ta != tb {
+		return cmp.Compare(ta, tb)
+	}
+	switch av := a.(type) {
+	case nil, primitive.Undefined:
+		return 0
+	case string:
+		return strings.Compare(av, b.(string))
+	case bool:
+		bv := b.(bool)
+		switch {
+		case av == bv:
+			return 0
+		case bv:
+			return -1
+		}
+		return 1
+	case primitive.ObjectID:
+		bv := b.(primitive.ObjectID)
+		return bytes.Compare(av[:], bv[:])
+	case primitive.DateTime:
+		return cmp.Compare(av, b.(primitive.DateTime))
+	case bson.A:
+		bv := b.(bson.A)
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if c := compare(av[i], bv[i]); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(av), len(bv))
+	case bson.M, bson.D:
+		am, _ := asDoc(a)
+		bm, _ := asDoc(b)
+		return compareDocs(am, bm)
+	}
+	if x, _, ok := number(a); ok {
+		y, _, _ := number(b)
+		return cmp.Compare(x, y)
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// compareDocs orders documents by their fields in key order, since a map
+// does not keep the order they were written in.
+func compareDocs(a, b bson.M) int {
+	keys := func(doc bson.M) []string {
+		names := make([]string, 0, len(doc))
+		for name := range doc {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	ak, bk := keys(a), keys(b)
+	for i := 0; i < len(ak) && i < len(bk); i++ {
+		if c := strings.Compare(ak[i], bk[i]); c != 0 {
+			return c
+		}
+		if c := compare(a[ak[i]], b[bk[i]]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(ak), len(bk))
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+type RelationRepository struct {
+	collection *collection
+}
+
+func NewRelationRepository(db *Database) *RelationRepository {
+	c := db.collection("relations")
+	c.ensureUnique("from.type", "from.id", "to.type", "to.id", "type")
+	return &RelationRepository{collection: c}
+}
+
+func (r *RelationRepository) Create(ctx context.Context, relation *models.Relation) error {
+	relation.ID = primitive.NewObjectID()
+	relation.CreatedAt = time.Now()
+
+	err := r.collection.insert(relation)
+	if mongo.IsDuplicateKeyError(err) {
+		return repository.ErrRelationExists
+	}
+	return err
+}
+
+func (r *RelationRepository) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Relation, int64, error) {
+	total, err := r.collection.count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	docs, err := r.collection.find(filter, bson.D{{Key: "created_at", Value: -1}}, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	relations, err := decodeAll[models.Relation](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return relations, total, nil
+}
+
+func (r *RelationRepository) GetByID(ctx context.Context, id string) (*models.Relation, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return findOne[models.Relation](r.collection, bson.M{"_id": objectID})
+}
+
+// ForItem returns every relation with the item at either end.
+func (r *RelationRepository) ForItem(ctx context.Context, ref models.ResourceRef) ([]models.Relation, error) {
+	relations, _, err := r.GetAll(ctx, repository.ItemFilter(ref), 0, 0)
+	return relations, err
+}
+
+func (r *RelationRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.collection.delete(bson.M{"_id": objectID}, false)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteMany removes the relations matching filter.
+func (r *RelationRepository) DeleteMany(ctx context.Context, filter bson.M) error {
+	_, err := r.collection.delete(filter, true)
+	return err
+}
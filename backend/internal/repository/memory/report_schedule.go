@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/models"
+)
+
+type ReportScheduleRepository struct {
+	items[models.ReportSchedule]
+}
+
+func NewReportScheduleRepository(db *Database) *ReportScheduleRepository {
+	return &ReportScheduleRepository{items[models.ReportSchedule]{
+		collection: db.collection("report_schedules"),
+		sort:       bson.D{{Key: "name", Value: 1}},
+		owned:      []string{"last_run_at", "last_status"},
+	}}
+}
+
+// Due returns the active schedules whose next run is at or before now.
+func (r *ReportScheduleRepository) Due(ctx context.Context, now time.Time) ([]models.ReportSchedule, error) {
+	filter := bson.M{
+		"is_active":   true,
+		"next_run_at": bson.M{"$lte": now},
+	}
+	docs, err := r.collection.find(filter, bson.D{{Key: "next_run_at", Value: 1}}, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll[models.ReportSchedule](docs)
+}
+
+// Claim moves a due schedule from its current next run to next. It reports
+// false when another process already moved it, so each run fires once.
+// Claims do not bump the version, so they never conflict with user edits.
+func (r *ReportScheduleRepository) Claim(ctx context.Context, id primitive.ObjectID, current time.Time, next *time.Time) (bool, error) {
+	matched, err := r.collection.update(
+		bson.M{"_id": id, "next_run_at": current},
+		bson.M{"$set": bson.M{"next_run_at": next}},
+		false, false,
+	)
+	if err != nil {
+		return false, err
+	}
+	return matched == 1, nil
+}
+
+// SetLastRun records the outcome of the latest run on the schedule.
+func (r *ReportScheduleRepository) SetLastRun(ctx context.Context, id primitive.ObjectID, at time.Time, status models.ReportRunStatus) error {
+	_, err := r.collection.update(
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"last_run_at": at, "last_status": status}},
+		false, false,
+	)
+	return err
+}
+
+type ReportRunRepository struct {
+	collection *collection
+}
+
+func NewReportRunRepository(db *Database) *ReportRunRepository {
+	return &ReportRunRepository{collection: db.collection("report_runs")}
+}
+
+func (r *ReportRunRepository) Create(ctx context.Context, run *models.ReportRun) error {
+	run.ID = primitive.NewObjectID()
+	run.StartedAt = time.Now()
+
+	return r.collection.insert(run)
+}
+
+// Finish stores the outcome of a run.
+func (r *ReportRunRepository) Finish(ctx context.Context, run *models.ReportRun) error {
+	now := time.Now()
+	run.FinishedAt = &now
+
+	_, err := r.collection.update(
+		bson.M{"_id": run.ID},
+		bson.M{"$set": bson.M{
+			"status":      run.Status,
+			"filename":    run.Filename,
+			"size":        run.Size,
+			"error":       run.Error,
+			"finished_at": run.FinishedAt,
+		}},
+		false, false,
+	)
+	return err
+}
+
+// GetBySchedule returns the runs of a schedule, newest first.
+func (r *ReportRunRepository) GetBySchedule(ctx context.Context, scheduleID primitive.ObjectID, limit, offset int64) ([]models.ReportRun, int64, error) {
+	filter := bson.M{"schedule_id": scheduleID}
+	total, err := r.collection.count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	docs, err := r.collection.find(filter, bson.D{{Key: "started_at", Value: -1}}, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	runs, err := decodeAll[models.ReportRun](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
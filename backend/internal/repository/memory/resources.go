@@ -0,0 +1,20 @@
+package memory
+
+import "naradai-backend/internal/resource"
+
+// ResourceRepository stores the items of a resource, the counterpart of
+// resource.Repository.
+type ResourceRepository[T any, P resource.Model[T]] struct {
+	ordered[T]
+}
+
+func NewResourceRepository[T any, P resource.Model[T]](db *Database, r *resource.Resource[T, P]) *ResourceRepository[T, P] {
+	return &ResourceRepository[T, P]{ordered[T]{items[T]{
+		collection: db.collection(r.Name),
+		sort:       resource.ListSort,
+		owned:      r.OwnedFields(),
+		prepare:    r.Defaults,
+		active:     r.Active,
+		linked:     r.Linked,
+	}}}
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+type SnapshotRepository struct {
+	collection *collection
+}
+
+func NewSnapshotRepository(db *Database) *SnapshotRepository {
+	c := db.collection("snapshots")
+	c.ensureUnique("date")
+	return &SnapshotRepository{collection: c}
+}
+
+// Save stores the snapshot. With replace set, an existing snapshot for the
+// same date is overwritten, keeping its ID; otherwise ErrSnapshotExists is
+// returned.
+func (r *SnapshotRepository) Save(ctx context.Context, snapshot *models.Snapshot, replace bool) error {
+	snapshot.ID = primitive.NewObjectID()
+	snapshot.CreatedAt = time.Now()
+
+	if !replace {
+		err := r.collection.insert(snapshot)
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrSnapshotExists
+		}
+		return err
+	}
+
+	existing, err := findOne[models.Snapshot](r.collection, bson.M{"date": snapshot.Date})
+	if err == nil {
+		snapshot.ID = existing.ID
+	} else if err != mongo.ErrNoDocuments {
+		return err
+	}
+	_, err = r.collection.replace(bson.M{"date": snapshot.Date}, snapshot, true)
+	return err
+}
+
+// GetAll lists snapshots newest first, without their items.
+func (r *SnapshotRepository) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Snapshot, int64, error) {
+	total, err := r.collection.count(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	docs, err := r.collection.find(filter, bson.D{{Key: "date", Value: -1}}, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, doc := range docs {
+		delete(doc, "dashboard")
+	}
+	snapshots, err := decodeAll[models.Snapshot](docs)
+	if err != nil {
+		return nil, 0, err
+	}
+	return snapshots, total, nil
+}
+
+func (r *SnapshotRepository) GetByID(ctx context.Context, id string) (*models.Snapshot, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	return findOne[models.Snapshot](r.collection, bson.M{"_id": objectID})
+}
+
+func (r *SnapshotRepository) GetByDate(ctx context.Context, date string) (*models.Snapshot, error) {
+	return findOne[models.Snapshot](r.collection, bson.M{"date": date})
+}
+
+func (r *SnapshotRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := r.collection.delete(bson.M{"_id": objectID}, false)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/models"
+)
+
+type StatPointRepository struct {
+	collection *collection
+}
+
+func NewStatPointRepository(db *Database) *StatPointRepository {
+	c := db.collection("dashboard_stat_points")
+	c.ensureUnique("stat_id", "at")
+	return &StatPointRepository{collection: c}
+}
+
+// Record stores the points, replacing any point already recorded for the
+// same stat and timestamp.
+func (r *StatPointRepository) Record(ctx context.Context, points []models.StatPoint) error {
+	for _, point := range points {
+		_, err := r.collection.update(
+			bson.M{"stat_id": point.StatID, "at": point.At},
+			bson.M{
+				"$set":         bson.M{"value": point.Value},
+				"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+			},
+			false, true,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Latest returns the most recent n points of the stat, newest first.
+func (r *StatPointRepository) Latest(ctx context.Context, statID primitive.ObjectID, n int64) ([]models.StatPoint, error) {
+	docs, err := r.collection.find(bson.M{"stat_id": statID}, bson.D{{Key: "at", Value: -1}}, 0, n)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll[models.StatPoint](docs)
+}
+
+// Range returns up to limit of the stat's most recent points between from
+// and to (either may be zero for an open end), oldest first.
+func (r *StatPointRepository) Range(ctx context.Context, statID primitive.ObjectID, from, to time.Time, limit int64) ([]models.StatPoint, error) {
+	filter := bson.M{"stat_id": statID}
+	at := bson.M{}
+	if !from.IsZero() {
+		at["$gte"] = from
+	}
+	if !to.IsZero() {
+		at["$lte"] = to
+	}
+	if len(at) > 0 {
+		filter["at"] = at
+	}
+
+	docs, err := r.collection.find(filter, bson.D{{Key: "at", Value: -1}}, 0, limit)
+	if err != nil {
+		return nil, err
+	}
+	points, err := decodeAll[models.StatPoint](docs)
+	if err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return points, nil
+}
+
+// DeleteByStat removes the whole series of a stat.
+func (r *StatPointRepository) DeleteByStat(ctx context.Context, statID primitive.ObjectID) error {
+	_, err := r.collection.delete(bson.M{"stat_id": statID}, true)
+	return err
+}
+
+// StatMetricRepository computes the aggregates behind bound dashboard stats
+// from the other resource collections.
+type StatMetricRepository struct {
+	clusters *collection
+	risks    *collection
+	actions  *collection
+}
+
+func NewStatMetricRepository(db *Database) *StatMetricRepository {
+	return &StatMetricRepository{
+		clusters: db.collection("conversation_clusters"),
+		risks:    db.collection("risks"),
+		actions:  db.collection("priority_actions"),
+	}
+}
+
+// Mentions returns the number of mentions across active conversation clusters
+// updated since the given time, and their mention-weighted average sentiment.
+// A zero since includes every active cluster.
+func (r *StatMetricRepository) Mentions(ctx context.Context, since time.Time) (count, sentiment float64, err error) {
+	match := bson.M{"is_active": true}
+	if !since.IsZero() {
+		match["updated_at"] = bson.M{"$gte": since}
+	}
+	docs, err := r.clusters.find(match, nil, 0, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var mentions, weighted float64
+	for _, doc := range docs {
+		// $sum skips values that are not numbers
+		size, _, _ := number(doc["size"])
+		score, _, _ := number(doc["sentiment"])
+		mentions += size
+		weighted += score * size
+	}
+	if mentions == 0 {
+		return 0, 0, nil
+	}
+	return mentions, weighted / mentions, nil
+}
+
+// RiskCount counts active risks, limited to one severity unless it is empty.
+func (r *StatMetricRepository) RiskCount(ctx context.Context, severity models.RiskSeverity) (int64, error) {
+	filter := bson.M{"is_active": true}
+	if severity != "" {
+		filter["severity"] = severity
+	}
+	return r.risks.count(filter)
+}
+
+// ActionCounts returns the number of priority actions and how many of them
+// are completed.
+func (r *StatMetricRepository) ActionCounts(ctx context.Context) (total, completed int64, err error) {
+	if total, err = r.actions.count(bson.M{}); err != nil {
+		return 0, 0, err
+	}
+	completed, err = r.actions.count(bson.M{"status": models.StatusCompleted})
+	return total, completed, err
+}
+
+// RiskCountsBySeverity returns the number of active risks of each severity.
+func (r *StatMetricRepository) RiskCountsBySeverity(ctx context.Context) (map[models.RiskSeverity]int64, error) {
+	counts := map[models.RiskSeverity]int64{}
+	err := countBy(r.risks, bson.M{"is_active": true}, "severity", func(key string, n int64) {
+		counts[models.RiskSeverity(key)] = n
+	})
+	return counts, err
+}
+
+// ActionCountsByStatus returns the number of priority actions in each status.
+// Actions saved without a status count as not started.
+func (r *StatMetricRepository) ActionCountsByStatus(ctx context.Context) (map[models.Status]int64, error) {
+	counts := map[models.Status]int64{}
+	err := countBy(r.actions, bson.M{}, "status", func(key string, n int64) {
+		status := models.Status(key)
+		if status == "" {
+			status = models.StatusNotStarted
+		}
+		counts[status] += n
+	})
+	return counts, err
+}
+
+func countBy(c *collection, match bson.M, field string, add func(key string, n int64)) error {
+	docs, err := c.find(match, nil, 0, 0)
+	if err != nil {
+		return err
+	}
+	groups := map[string]int64{}
+	for _, doc := range docs {
+		key, _ := doc[field].(string)
+		groups[key]++
+	}
+	for key, n := range groups {
+		add(key, n)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"naradai-backend/internal/models"
+)
+
+// ErrRelationExists is returned when the same two items are already linked
+// with the same relation type.
+var ErrRelationExists = errors.New("relation already exists")
+
+type RelationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRelationRepository(db *mongo.Database) *RelationRepository {
+	return &RelationRepository{
+		collection: db.Collection("relations"),
+	}
+}
+
+// EnsureIndexes keeps one relation of each type between two items and
+// indexes both ends for lookups by item.
+func (r *RelationRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "from.type", Value: 1}, {Key: "from.id", Value: 1},
+				{Key: "to.type", Value: 1}, {Key: "to.id", Value: 1},
+				{Key: "type", Value: 1},
+			},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "to.type", Value: 1}, {Key: "to.id", Value: 1}}},
+	})
+	return err
+}
+
+func (r *RelationRepository) Create(ctx context.Context, relation *models.Relation) error {
+	relation.ID = primitive.NewObjectID()
+	relation.CreatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, relation)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrRelationExists
+	}
+	return err
+}
+
+func (r *RelationRepository) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Relation, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSkip(offset).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var relations []models.Relation
+	if err = cursor.All(ctx, &relations); err != nil {
+		return nil, 0, err
+	}
+
+	return relations, total, nil
+}
+
+func (r *RelationRepository) GetByID(ctx context.Context, id string) (*models.Relation, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var relation models.Relation
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&relation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &relation, nil
+}
+
+// ForItem returns every relation with the item at either end.
+func (r *RelationRepository) ForItem(ctx context.Context, ref models.ResourceRef) ([]models.Relation, error) {
+	relations, _, err := r.GetAll(ctx, ItemFilter(ref), 0, 0)
+	return relations, err
+}
+
+// ItemFilter matches relations with the item at either end.
+func ItemFilter(ref models.ResourceRef) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"from.type": ref.Type, "from.id": ref.ID},
+		bson.M{"to.type": ref.Type, "to.id": ref.ID},
+	}}
+}
+
+func (r *RelationRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// DeleteMany removes the relations matching filter.
+func (r *RelationRepository) DeleteMany(ctx context.Context, filter bson.M) error {
+	_, err := r.collection.DeleteMany(ctx, filter)
+	return err
+}
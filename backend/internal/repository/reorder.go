@@ -0,0 +1,14 @@
+package repository
+
+import "errors"
+
+// Errors returned by OrderedStore.Reorder and Transactor.Transaction.
+var (
+	// ErrInvalidReorder is returned when the reorder list has malformed,
+	// duplicate or unknown IDs. Nothing is written in that case.
+	ErrInvalidReorder = errors.New("invalid reorder list")
+
+	// ErrTransactionsUnsupported is returned when MongoDB is a standalone
+	// server, which cannot run multi-document transactions.
+	ErrTransactionsUnsupported = errors.New("transactions are not supported by this MongoDB deployment")
+)
@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"naradai-backend/internal/models"
+)
+
+type ReportScheduleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReportScheduleRepository(db *mongo.Database) *ReportScheduleRepository {
+	return &ReportScheduleRepository{
+		collection: db.Collection("report_schedules"),
+	}
+}
+
+func (r *ReportScheduleRepository) Create(ctx context.Context, schedule *models.ReportSchedule) error {
+	schedule.ID = primitive.NewObjectID()
+	schedule.Version = 1
+	schedule.CreatedAt = time.Now()
+	schedule.UpdatedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, schedule)
+	return err
+}
+
+func (r *ReportScheduleRepository) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.ReportSchedule, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSkip(offset).
+		SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []models.ReportSchedule
+	if err = cursor.All(ctx, &schedules); err != nil {
+		return nil, 0, err
+	}
+
+	return schedules, total, nil
+}
+
+func (r *ReportScheduleRepository) GetByID(ctx context.Context, id string) (*models.ReportSchedule, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule models.ReportSchedule
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *ReportScheduleRepository) Update(ctx context.Context, id string, schedule *models.ReportSchedule, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	schedule.UpdatedAt = time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"name":        schedule.Name,
+			"cron":        schedule.Cron,
+			"timezone":    schedule.Timezone,
+			"recipients":  schedule.Recipients,
+			"format":      schedule.Format,
+			"period":      schedule.Period,
+			"resources":   schedule.Resources,
+			"is_active":   schedule.IsActive,
+			"next_run_at": schedule.NextRunAt,
+			"updated_at":  schedule.UpdatedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, VersionFilter(objectID, version), update)
+	if err != nil {
+		return err
+	}
+	return CheckMatched(ctx, r.collection, objectID, version, result.MatchedCount)
+}
+
+func (r *ReportScheduleRepository) Delete(ctx context.Context, id string, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, VersionFilter(objectID, version))
+	if err != nil {
+		return err
+	}
+	return CheckMatched(ctx, r.collection, objectID, version, result.DeletedCount)
+}
+
+// Due returns the active schedules whose next run is at or before now.
+func (r *ReportScheduleRepository) Due(ctx context.Context, now time.Time) ([]models.ReportSchedule, error) {
+	filter := bson.M{
+		"is_active":   true,
+		"next_run_at": bson.M{"$lte": now},
+	}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "next_run_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var schedules []models.ReportSchedule
+	if err = cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// Claim moves a due schedule from its current next run to next. It reports
+// false when another process already moved it, so each run fires once.
+// Claims do not bump the version, so they never conflict with user edits.
+func (r *ReportScheduleRepository) Claim(ctx context.Context, id primitive.ObjectID, current time.Time, next *time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "next_run_at": current},
+		bson.M{"$set": bson.M{"next_run_at": next}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// SetLastRun records the outcome of the latest run on the schedule.
+func (r *ReportScheduleRepository) SetLastRun(ctx context.Context, id primitive.ObjectID, at time.Time, status models.ReportRunStatus) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"last_run_at": at, "last_status": status}},
+	)
+	return err
+}
+
+type ReportRunRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReportRunRepository(db *mongo.Database) *ReportRunRepository {
+	return &ReportRunRepository{
+		collection: db.Collection("report_runs"),
+	}
+}
+
+func (r *ReportRunRepository) Create(ctx context.Context, run *models.ReportRun) error {
+	run.ID = primitive.NewObjectID()
+	run.StartedAt = time.Now()
+
+	_, err := r.collection.InsertOne(ctx, run)
+	return err
+}
+
+// Finish stores the outcome of a run.
+func (r *ReportRunRepository) Finish(ctx context.Context, run *models.ReportRun) error {
+	now := time.Now()
+	run.FinishedAt = &now
+
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": run.ID},
+		bson.M{"$set": bson.M{
+			"status":      run.Status,
+			"filename":    run.Filename,
+			"size":        run.Size,
+			"error":       run.Error,
+			"finished_at": run.FinishedAt,
+		}},
+	)
+	return err
+}
+
+// GetBySchedule returns the runs of a schedule, newest first.
+func (r *ReportRunRepository) GetBySchedule(ctx context.Context, scheduleID primitive.ObjectID, limit, offset int64) ([]models.ReportRun, int64, error) {
+	filter := bson.M{"schedule_id": scheduleID}
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSkip(offset).
+		SetSort(bson.D{{Key: "started_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var runs []models.ReportRun
+	if err = cursor.All(ctx, &runs); err != nil {
+		return nil, 0, err
+	}
+
+	return runs, total, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"naradai-backend/internal/models"
+)
+
+// ErrSnapshotExists is returned when a snapshot for the date already exists.
+var ErrSnapshotExists = errors.New("snapshot already exists for this date")
+
+type SnapshotRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSnapshotRepository(db *mongo.Database) *SnapshotRepository {
+	return &SnapshotRepository{
+		collection: db.Collection("snapshots"),
+	}
+}
+
+// EnsureIndexes makes the snapshot date unique.
+func (r *SnapshotRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// Save stores the snapshot. With replace set, an existing snapshot for the
+// same date is overwritten; otherwise ErrSnapshotExists is returned.
+func (r *SnapshotRepository) Save(ctx context.Context, snapshot *models.Snapshot, replace bool) error {
+	snapshot.ID = primitive.NewObjectID()
+	snapshot.CreatedAt = time.Now()
+
+	if !replace {
+		_, err := r.collection.InsertOne(ctx, snapshot)
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrSnapshotExists
+		}
+		return err
+	}
+
+	var existing models.Snapshot
+	err := r.collection.FindOne(ctx, bson.M{"date": snapshot.Date}, options.FindOne().SetProjection(bson.M{"_id": 1})).Decode(&existing)
+	if err == nil {
+		snapshot.ID = existing.ID
+	} else if err != mongo.ErrNoDocuments {
+		return err
+	}
+	_, err = r.collection.ReplaceOne(ctx, bson.M{"date": snapshot.Date}, snapshot, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetAll lists snapshots newest first, without their items.
+func (r *SnapshotRepository) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Snapshot, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSkip(offset).
+		SetSort(bson.D{{Key: "date", Value: -1}}).
+		SetProjection(bson.M{"dashboard": 0})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []models.Snapshot
+	if err = cursor.All(ctx, &snapshots); err != nil {
+		return nil, 0, err
+	}
+
+	return snapshots, total, nil
+}
+
+func (r *SnapshotRepository) GetByID(ctx context.Context, id string) (*models.Snapshot, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot models.Snapshot
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+func (r *SnapshotRepository) GetByDate(ctx context.Context, date string) (*models.Snapshot, error) {
+	var snapshot models.Snapshot
+	err := r.collection.FindOne(ctx, bson.M{"date": date}).Decode(&snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+func (r *SnapshotRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
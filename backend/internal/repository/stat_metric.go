@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+)
+
+// StatMetricRepository runs the aggregate queries behind bound dashboard
+// stats against the other resource collections.
+type StatMetricRepository struct {
+	clusters *mongo.Collection
+	risks    *mongo.Collection
+	actions  *mongo.Collection
+}
+
+func NewStatMetricRepository(db *mongo.Database) *StatMetricRepository {
+	return &StatMetricRepository{
+		clusters: db.Collection("conversation_clusters"),
+		risks:    db.Collection("risks"),
+		actions:  db.Collection("priority_actions"),
+	}
+}
+
+// Mentions returns the number of mentions across active conversation clusters
+// updated since the given time, and their mention-weighted average sentiment.
+// A zero since includes every active cluster.
+func (r *StatMetricRepository) Mentions(ctx context.Context, since time.Time) (count, sentiment float64, err error) {
+	match := bson.M{"is_active": true}
+	if !since.IsZero() {
+		match["updated_at"] = bson.M{"$gte": since}
+	}
+
+	cursor, err := r.clusters.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      nil,
+			"mentions": bson.M{"$sum": "$size"},
+			"weighted": bson.M{"$sum": bson.M{"$multiply": bson.A{"$sentiment", "$size"}}},
+		}}},
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var totals []struct {
+		Mentions float64 `bson:"mentions"`
+		Weighted float64 `bson:"weighted"`
+	}
+	if err := cursor.All(ctx, &totals); err != nil {
+		return 0, 0, err
+	}
+	if len(totals) == 0 || totals[0].Mentions == 0 {
+		return 0, 0, nil
+	}
+	return totals[0].Mentions, totals[0].Weighted / totals[0].Mentions, nil
+}
+
+// RiskCount counts active risks, limited to one severity unless it is empty.
+func (r *StatMetricRepository) RiskCount(ctx context.Context, severity models.RiskSeverity) (int64, error) {
+	filter := bson.M{"is_active": true}
+	if severity != "" {
+		filter["severity"] = severity
+	}
+	return r.risks.CountDocuments(ctx, filter)
+}
+
+// ActionCounts returns the number of priority actions and how many of them
+// are completed.
+func (r *StatMetricRepository) ActionCounts(ctx context.Context) (total, completed int64, err error) {
+	if total, err = r.actions.CountDocuments(ctx, bson.M{}); err != nil {
+		return 0, 0, err
+	}
+	completed, err = r.actions.CountDocuments(ctx, bson.M{"status": models.StatusCompleted})
+	return total, completed, err
+}
+
+// RiskCountsBySeverity returns the number of active risks of each severity.
+func (r *StatMetricRepository) RiskCountsBySeverity(ctx context.Context) (map[models.RiskSeverity]int64, error) {
+	counts := map[models.RiskSeverity]int64{}
+	err := r.countBy(ctx, r.risks, bson.M{"is_active": true}, "$severity", func(key string, n int64) {
+		counts[models.RiskSeverity(key)] = n
+	})
+	return counts, err
+}
+
+// ActionCountsByStatus returns the number of priority actions in each status.
+// Actions saved without a status count as not started.
+func (r *StatMetricRepository) ActionCountsByStatus(ctx context.Context) (map[models.Status]int64, error) {
+	counts := map[models.Status]int64{}
+	err := r.countBy(ctx, r.actions, bson.M{}, "$status", func(key string, n int64) {
+		status := models.Status(key)
+		if status == "" {
+			status = models.StatusNotStarted
+		}
+		counts[status] += n
+	})
+	return counts, err
+}
+
+func (r *StatMetricRepository) countBy(ctx context.Context, coll *mongo.Collection, match bson.M, field string, add func(key string, n int64)) error {
+	cursor, err := coll.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{"_id": field, "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Key   string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return err
+	}
+	for _, g := range groups {
+		add(g.Key, g.Count)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"naradai-backend/internal/models"
+)
+
+type StatPointRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStatPointRepository(db *mongo.Database) *StatPointRepository {
+	return &StatPointRepository{
+		collection: db.Collection("dashboard_stat_points"),
+	}
+}
+
+// EnsureIndexes keeps one point per stat and timestamp.
+func (r *StatPointRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "stat_id", Value: 1}, {Key: "at", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// Record stores the points, replacing any point already recorded for the
+// same stat and timestamp.
+func (r *StatPointRepository) Record(ctx context.Context, points []models.StatPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, len(points))
+	for i := range points {
+		point := &points[i]
+		writes[i] = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"stat_id": point.StatID, "at": point.At}).
+			SetUpdate(bson.M{
+				"$set":         bson.M{"value": point.Value},
+				"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+			}).
+			SetUpsert(true)
+	}
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// Latest returns the most recent n points of the stat, newest first.
+func (r *StatPointRepository) Latest(ctx context.Context, statID primitive.ObjectID, n int64) ([]models.StatPoint, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "at", Value: -1}}).
+		SetLimit(n)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"stat_id": statID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var points []models.StatPoint
+	if err = cursor.All(ctx, &points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// Range returns up to limit of the stat's most recent points between from
+// and to (either may be zero for an open end), oldest first.
+func (r *StatPointRepository) Range(ctx context.Context, statID primitive.ObjectID, from, to time.Time, limit int64) ([]models.StatPoint, error) {
+	filter := bson.M{"stat_id": statID}
+	at := bson.M{}
+	if !from.IsZero() {
+		at["$gte"] = from
+	}
+	if !to.IsZero() {
+		at["$lte"] = to
+	}
+	if len(at) > 0 {
+		filter["at"] = at
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "at", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var points []models.StatPoint
+	if err = cursor.All(ctx, &points); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+		points[i], points[j] = points[j], points[i]
+	}
+	return points, nil
+}
+
+// DeleteByStat removes the whole series of a stat.
+func (r *StatPointRepository) DeleteByStat(ctx context.Context, statID primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(ctx, bson.M{"stat_id": statID})
+	return err
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/models"
+)
+
+// The Store interfaces are what the services need from each repository. The
+// MongoDB repositories in this package and in internal/resource implement
+// them, and so does the in-memory store in internal/repository/memory, which
+// tests run against.
+//
+// Implementations report a missing document as mongo.ErrNoDocuments, a stale
+// version as ErrVersionConflict and a bad reorder list as ErrInvalidReorder,
+// and match filters, sort and paginate the way MongoDB does.
+
+// ItemStore is the storage of a resource edited through the API. A limit of
+// 0 lists every item.
+type ItemStore[T any] interface {
+	Create(ctx context.Context, item *T) error
+	GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]T, int64, error)
+	Each(ctx context.Context, filter bson.M, fn func(*T) error) error
+	GetByID(ctx context.Context, id string) (*T, error)
+	Update(ctx context.Context, id string, item *T, version int64) error
+	Patch(ctx context.Context, id string, fields bson.M, version int64) error
+	Delete(ctx context.Context, id string, version int64) error
+}
+
+// OrderedStore is an ItemStore whose items the dashboard shows in a
+// user-defined order.
+type OrderedStore[T any] interface {
+	ItemStore[T]
+	Reorder(ctx context.Context, ids []string) error
+}
+
+// Transactor is implemented by the MongoDB stores, which can make writes to
+// several collections in one transaction. Transaction runs fn with the
+// context of the transaction's session; the stores fn passes it to write as
+// part of the transaction. It returns ErrTransactionsUnsupported, having
+// written nothing, when MongoDB is a standalone server.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type StatPointStore interface {
+	Record(ctx context.Context, points []models.StatPoint) error
+	Latest(ctx context.Context, statID primitive.ObjectID, n int64) ([]models.StatPoint, error)
+	Range(ctx context.Context, statID primitive.ObjectID, from, to time.Time, limit int64) ([]models.StatPoint, error)
+	DeleteByStat(ctx context.Context, statID primitive.ObjectID) error
+}
+
+type StatMetricStore interface {
+	Mentions(ctx context.Context, since time.Time) (count, sentiment float64, err error)
+	RiskCount(ctx context.Context, severity models.RiskSeverity) (int64, error)
+	ActionCounts(ctx context.Context) (total, completed int64, err error)
+	RiskCountsBySeverity(ctx context.Context) (map[models.RiskSeverity]int64, error)
+	ActionCountsByStatus(ctx context.Context) (map[models.Status]int64, error)
+}
+
+type RelationStore interface {
+	Create(ctx context.Context, relation *models.Relation) error
+	GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Relation, int64, error)
+	GetByID(ctx context.Context, id string) (*models.Relation, error)
+	ForItem(ctx context.Context, ref models.ResourceRef) ([]models.Relation, error)
+	Delete(ctx context.Context, id string) error
+	DeleteMany(ctx context.Context, filter bson.M) error
+}
+
+type EvidenceStore interface {
+	Create(ctx context.Context, evidence ...*models.Evidence) error
+	GetByItem(ctx context.Context, item models.ResourceRef) ([]models.Evidence, error)
+	CountByItem(ctx context.Context, item models.ResourceRef) (int64, error)
+	Delete(ctx context.Context, item models.ResourceRef, id string) error
+	DeleteAutoSelected(ctx context.Context, item models.ResourceRef) error
+	DeleteByItem(ctx context.Context, item models.ResourceRef) error
+}
+
+type SnapshotStore interface {
+	Save(ctx context.Context, snapshot *models.Snapshot, replace bool) error
+	GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Snapshot, int64, error)
+	GetByID(ctx context.Context, id string) (*models.Snapshot, error)
+	GetByDate(ctx context.Context, date string) (*models.Snapshot, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type ReportScheduleStore interface {
+	Create(ctx context.Context, schedule *models.ReportSchedule) error
+	GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.ReportSchedule, int64, error)
+	GetByID(ctx context.Context, id string) (*models.ReportSchedule, error)
+	Update(ctx context.Context, id string, schedule *models.ReportSchedule, version int64) error
+	Delete(ctx context.Context, id string, version int64) error
+	Due(ctx context.Context, now time.Time) ([]models.ReportSchedule, error)
+	Claim(ctx context.Context, id primitive.ObjectID, current time.Time, next *time.Time) (bool, error)
+	SetLastRun(ctx context.Context, id primitive.ObjectID, at time.Time, status models.ReportRunStatus) error
+}
+
+type ReportRunStore interface {
+	Create(ctx context.Context, run *models.ReportRun) error
+	Finish(ctx context.Context, run *models.ReportRun) error
+	GetBySchedule(ctx context.Context, scheduleID primitive.ObjectID, limit, offset int64) ([]models.ReportRun, int64, error)
+}
+
+var (
+	_ StatPointStore      = (*StatPointRepository)(nil)
+	_ StatMetricStore     = (*StatMetricRepository)(nil)
+	_ RelationStore       = (*RelationRepository)(nil)
+	_ EvidenceStore       = (*EvidenceRepository)(nil)
+	_ SnapshotStore       = (*SnapshotRepository)(nil)
+	_ ReportScheduleStore = (*ReportScheduleRepository)(nil)
+	_ ReportRunStore      = (*ReportRunRepository)(nil)
+)
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnyVersion disables the optimistic concurrency check on a write.
+const AnyVersion int64 = -1
+
+// ErrVersionConflict is returned when a conditional write targets a document
+// whose version has moved on since the caller read it.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionFilter matches the document by ID and, unless version is AnyVersion,
+// by its current version.
+func VersionFilter(objectID primitive.ObjectID, version int64) bson.M {
+	filter := bson.M{"_id": objectID}
+	switch {
+	case version == AnyVersion:
+	case version == 0:
+		// Documents written before versioning was introduced have no version field
+		filter["version"] = bson.M{"$in": bson.A{0, nil}}
+	default:
+		filter["version"] = version
+	}
+	return filter
+}
+
+// CheckMatched turns a conditional write that matched nothing into
+// mongo.ErrNoDocuments or ErrVersionConflict.
+func CheckMatched(ctx context.Context, collection *mongo.Collection, objectID primitive.ObjectID, version int64, matched int64) error {
+	if matched > 0 {
+		return nil
+	}
+	if version == AnyVersion {
+		return mongo.ErrNoDocuments
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return ErrVersionConflict
+}
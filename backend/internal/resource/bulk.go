@@ -0,0 +1,199 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/repository"
+)
+
+type bulkOperation struct {
+	Op      string          `json:"op" binding:"required,oneof=create update delete"`
+	ID      string          `json:"id"`
+	Version *int64          `json:"version"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type bulkRequest struct {
+	Operations []bulkOperation `json:"operations" binding:"required,min=1,max=500,dive"`
+}
+
+type bulkResult struct {
+	Index   int                    `json:"index"`
+	Op      string                 `json:"op"`
+	ID      string                 `json:"id,omitempty"`
+	Success bool                   `json:"success"`
+	Status  int                    `json:"status"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// bulkActions binds a resource's service methods for runBulk.
+type bulkActions[T any] struct {
+	create  func(ctx context.Context, item *T) error
+	update  func(ctx context.Context, id string, item *T, version int64) error
+	delete  func(ctx context.Context, id string, version int64) error
+	get     func(ctx context.Context, id string) (*T, error)
+	respond func(item *T) map[string]interface{}
+}
+
+// runBulk handles POST /api/v1/:resource/bulk. Operations are applied in
+// order and independently; a failed item does not stop the others.
+func runBulk[T any](c *gin.Context, actions bulkActions[T]) {
+	var req bulkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	results := make([]bulkResult, len(req.Operations))
+	succeeded := 0
+	for i, op := range req.Operations {
+		result := bulkResult{Index: i, Op: op.Op, ID: op.ID}
+		version := repository.AnyVersion
+		if op.Version != nil {
+			version = *op.Version
+		}
+
+		var err error
+		switch op.Op {
+		case "create":
+			var item T
+			if err = decodeBulkData(op.Data, &item); err == nil {
+				if err = actions.create(ctx, &item); err == nil {
+					result.Status = http.StatusCreated
+					result.Data = actions.respond(&item)
+					result.ID, _ = result.Data["id"].(string)
+				}
+			}
+		case "update":
+			var item T
+			if op.ID == "" {
+				err = errBulkMissingID
+			} else if err = decodeBulkData(op.Data, &item); err == nil {
+				if err = actions.update(ctx, op.ID, &item, version); err == nil {
+					result.Status = http.StatusOK
+					if updated, getErr := actions.get(ctx, op.ID); getErr == nil {
+						result.Data = actions.respond(updated)
+					}
+				}
+			}
+		case "delete":
+			if op.ID == "" {
+				err = errBulkMissingID
+			} else if err = actions.delete(ctx, op.ID, version); err == nil {
+				result.Status = http.StatusOK
+			}
+		}
+
+		if err != nil {
+			result.Status, result.Error = bulkErrorStatus(err)
+			if result.Status >= http.StatusInternalServerError {
+				// The item result only says the operation failed
+				slog.ErrorContext(ctx, "bulk operation failed", "index", i, "op", op.Op, "id", op.ID, "error", err)
+			}
+		} else {
+			result.Success = true
+			succeeded++
+		}
+		results[i] = result
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   succeeded == len(results),
+		"data":      results,
+		"total":     len(results),
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+	})
+}
+
+var (
+	errBulkMissingID   = errors.New("id is required")
+	errBulkMissingData = errors.New("data is required")
+	errBulkInvalidData = errors.New("invalid data")
+)
+
+func decodeBulkData(data json.RawMessage, dst interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return errBulkMissingData
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("%w: %v", errBulkInvalidData, err)
+	}
+	return nil
+}
+
+func bulkErrorStatus(err error) (int, string) {
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, repository.ErrVersionConflict):
+		return http.StatusPreconditionFailed, err.Error()
+	case errors.As(err, &validationErrs):
+		return http.StatusBadRequest, "validation failed: " + validationErrs.Error()
+	case errors.Is(err, primitive.ErrInvalidHex):
+		return http.StatusBadRequest, "invalid id"
+	case errors.Is(err, errBulkMissingID), errors.Is(err, errBulkMissingData), errors.Is(err, errBulkInvalidData):
+		return http.StatusBadRequest, err.Error()
+	default:
+		return http.StatusInternalServerError, "failed to apply operation"
+	}
+}
+
+type reorderRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=1000"`
+}
+
+// runReorder handles POST /api/v1/:resource/reorder. The order field of each
+// listed document is rewritten to its position in ids.
+func runReorder(c *gin.Context, reorder func(ctx context.Context, ids []string) error) {
+	var req reorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := reorder(c.Request.Context(), req.IDs); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidReorder):
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+		case errors.Is(err, repository.ErrTransactionsUnsupported):
+			c.JSON(http.StatusNotImplemented, gin.H{
+				"success": false,
+				"error":   "Reordering requires MongoDB running as a replica set",
+			})
+		default:
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to reorder items",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Order updated successfully",
+		"total":   len(req.IDs),
+	})
+}
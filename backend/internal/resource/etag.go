@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/repository"
+)
+
+// etag returns the entity tag of a document version, e.g. "6650c0...-3".
+func etag(id primitive.ObjectID, version int64) string {
+	return fmt.Sprintf(`"%s-%d"`, id.Hex(), version)
+}
+
+// SetETag sets the ETag response header for a document version.
+func SetETag(c *gin.Context, id primitive.ObjectID, version int64) {
+	c.Header("ETag", etag(id, version))
+}
+
+// setListETag sets a weak ETag for a page of items listed with filter, which
+// changes whenever an item on the page, the page bounds or the total do.
+func setListETag[T any, P Model[T]](c *gin.Context, filter bson.M, limit, offset, total int64, items []T) {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%v %d %d %d", filter, limit, offset, total)
+	for i := range items {
+		fmt.Fprintf(hash, " %s-%d", P(&items[i]).ItemID().Hex(), P(&items[i]).ItemVersion())
+	}
+	c.Header("ETag", `W/"`+hex.EncodeToString(hash.Sum(nil)[:16])+`"`)
+}
+
+// NotModified writes 304 Not Modified and returns true when the request's
+// If-None-Match header matches the ETag already set on the response.
+func NotModified(c *gin.Context) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	current := strings.TrimPrefix(c.Writer.Header().Get("ETag"), "W/")
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// IfMatchVersion returns the document version required by the If-Match
+// header, or repository.AnyVersion when the header is absent or "*". When the
+// header can never match the document addressed by :id, a 412 response is
+// written and ok is false.
+func IfMatchVersion(c *gin.Context) (version int64, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return repository.AnyVersion, true
+	}
+
+	if strings.Contains(header, ",") {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "If-Match must contain a single entity tag",
+		})
+		return 0, false
+	}
+
+	tag := strings.Trim(header, `"`)
+	sep := strings.LastIndex(tag, "-")
+	if sep > 0 && tag[:sep] == c.Param("id") {
+		if version, err := strconv.ParseInt(tag[sep+1:], 10, 64); err == nil && version >= 0 {
+			return version, true
+		}
+	}
+
+	RespondVersionConflict(c)
+	return 0, false
+}
+
+// RespondVersionConflict writes 412 Precondition Failed.
+func RespondVersionConflict(c *gin.Context) {
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"success": false,
+		"error":   "Resource has been modified by another request, reload it and try again",
+	})
+}
@@ -0,0 +1,147 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson"
+	"naradai-backend/internal/tabular"
+)
+
+// exportFlushEvery is how many rows are buffered before flushing to the client.
+const exportFlushEvery = 200
+
+// runExport handles GET /api/v1/:resource/export?format=csv|xlsx|json. Items
+// matching filter are streamed from the database cursor into the response.
+func runExport[T any](c *gin.Context, name string, filter bson.M, each func(ctx context.Context, filter bson.M, fn func(*T) error) error) {
+	format, err := tabular.ParseFormat(c.DefaultQuery("format", "csv"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	columns := tabular.Columns(reflect.TypeOf((*T)(nil)))
+
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("2006-01-02"), format)
+		c.Header("Content-Type", format.ContentType())
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+		c.Status(http.StatusOK)
+	}
+
+	switch format {
+	case tabular.CSV:
+		w := tabular.NewCSVWriter(c.Writer)
+		rows := 0
+		writeHeader := func() error {
+			start()
+			return w.WriteHeader(tabular.Names(columns))
+		}
+		err = each(ctx, filter, func(item *T) error {
+			if rows == 0 {
+				if err := writeHeader(); err != nil {
+					return err
+				}
+			}
+			if err := w.WriteRow(tabular.Values(columns, item)); err != nil {
+				return err
+			}
+			if rows++; rows%exportFlushEvery == 0 {
+				if err := w.Flush(); err != nil {
+					return err
+				}
+				c.Writer.Flush()
+			}
+			return nil
+		})
+		if err == nil && rows == 0 {
+			err = writeHeader()
+		}
+		if flushErr := w.Flush(); err == nil {
+			err = flushErr
+		}
+
+	case tabular.JSON:
+		count := 0
+		err = each(ctx, filter, func(item *T) error {
+			encoded, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			start()
+			if count == 0 {
+				c.Writer.WriteString("[")
+			} else {
+				c.Writer.WriteString(",")
+			}
+			c.Writer.Write(encoded)
+			if count++; count%exportFlushEvery == 0 {
+				c.Writer.Flush()
+			}
+			return nil
+		})
+		if err == nil {
+			start()
+			if count == 0 {
+				c.Writer.WriteString("[")
+			}
+			c.Writer.WriteString("]")
+		}
+
+	case tabular.XLSX:
+		book := tabular.NewWorkbook()
+		var sheet tabular.RowWriter
+		if sheet, err = book.AddSheet(sheetTitle(name)); err == nil {
+			if err = sheet.WriteHeader(tabular.Names(columns)); err == nil {
+				err = each(ctx, filter, func(item *T) error {
+					return sheet.WriteRow(tabular.Values(columns, item))
+				})
+			}
+		}
+		if err == nil {
+			start()
+			_, err = book.WriteTo(c.Writer)
+		}
+	}
+
+	if err != nil {
+		if !started {
+			c.Error(err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "Failed to export " + strings.ReplaceAll(name, "-", " "),
+			})
+			return
+		}
+		// Headers are already sent; the client receives a truncated file
+		slog.ErrorContext(c.Request.Context(), "export failed mid-stream", "resource", name, "error", err)
+		c.Abort()
+	}
+}
+
+// sheetTitle turns a route name such as "priority-actions" into "Priority Actions".
+func sheetTitle(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,404 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/repository"
+)
+
+// Handler serves the routes every resource has.
+type Handler[T any, P Model[T]] struct {
+	service  *Service[T, P]
+	resource *Resource[T, P]
+
+	// Present renders an item in list and get responses. It defaults to the
+	// model's ToResponse.
+	Present func(item *T) map[string]interface{}
+	// Cacheable reports whether a get of the item, or a list holding it, may
+	// be answered with 304 Not Modified, which an item that changes without a
+	// new version must not be. By default every item is.
+	Cacheable func(item *T) bool
+}
+
+func NewHandler[T any, P Model[T]](svc *Service[T, P]) *Handler[T, P] {
+	return &Handler[T, P]{
+		service:  svc,
+		resource: svc.Resource(),
+		Present: func(item *T) map[string]interface{} {
+			return P(item).ToResponse()
+		},
+		Cacheable: func(item *T) bool { return true },
+	}
+}
+
+// Register adds the routes of the resource to api, the /api/v1 group.
+func (h *Handler[T, P]) Register(api gin.IRouter) {
+	path := h.resource.Path()
+	api.GET(path, h.GetAll)
+	api.GET(path+"/export", h.Export)
+	api.GET(path+"/:id", h.GetByID)
+	api.POST(path, h.Create)
+	api.POST(path+"/bulk", h.Bulk)
+	api.POST(path+"/import", h.Import)
+	api.POST(path+"/reorder", h.Reorder)
+	api.PUT(path+"/:id", h.Update)
+	api.PATCH(path+"/:id", h.Patch)
+	api.DELETE(path+"/:id", h.Delete)
+}
+
+// GetAll handles GET /api/v1/:resource
+func (h *Handler[T, P]) GetAll(c *gin.Context) {
+	limit, _ := strconv.ParseInt(c.DefaultQuery("limit", "100"), 10, 64)
+	offset, _ := strconv.ParseInt(c.DefaultQuery("offset", "0"), 10, 64)
+
+	filter := h.filter(c)
+	items, total, err := h.service.GetAll(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch " + h.resource.plural(),
+		})
+		return
+	}
+
+	cacheable := true
+	for i := range items {
+		cacheable = cacheable && h.Cacheable(&items[i])
+	}
+	if cacheable {
+		setListETag[T, P](c, filter, limit, offset, total, items)
+		if NotModified(c) {
+			return
+		}
+	}
+
+	data := make([]map[string]interface{}, len(items))
+	for i := range items {
+		data[i] = h.Present(&items[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   total,
+	})
+}
+
+// filter builds the list filter from query parameters, shared by GetAll and Export.
+func (h *Handler[T, P]) filter(c *gin.Context) bson.M {
+	filter := bson.M{}
+	if h.resource.Active {
+		isActive := c.Query("is_active")
+		if isActive == "true" {
+			filter["is_active"] = true
+		} else if isActive == "false" {
+			filter["is_active"] = false
+		}
+	}
+	if h.resource.Linked {
+		flagged := c.Query("flagged")
+		if flagged == "true" {
+			filter["flagged_reason"] = bson.M{"$nin": bson.A{nil, ""}}
+		} else if flagged == "false" {
+			filter["flagged_reason"] = bson.M{"$in": bson.A{nil, ""}}
+		}
+	}
+	for _, field := range h.resource.Filters {
+		if value := c.Query(field); value != "" {
+			filter[field] = value
+		}
+	}
+	return filter
+}
+
+// GetByID handles GET /api/v1/:resource/:id. Items that can be linked to
+// others are returned with them on ?expand=related.
+func (h *Handler[T, P]) GetByID(c *gin.Context) {
+	item, err := h.service.GetByID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.RespondWriteError(c, err, "fetch")
+		return
+	}
+
+	expandRelated := h.service.HasRelated() && expands(c, "related")
+	SetETag(c, P(item).ItemID(), P(item).ItemVersion())
+	if !expandRelated && h.Cacheable(item) && NotModified(c) {
+		return
+	}
+
+	data := h.Present(item)
+	if expandRelated && !addRelated(c, data, P(item).ItemID(), h.service.Related) {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// Create handles POST /api/v1/:resource
+func (h *Handler[T, P]) Create(c *gin.Context) {
+	var item T
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Validate(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Validation failed: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Create(c.Request.Context(), &item); err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to create " + h.resource.Label,
+		})
+		return
+	}
+
+	SetETag(c, P(&item).ItemID(), P(&item).ItemVersion())
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"message": h.resource.title() + " created successfully",
+		"data":    P(&item).ToResponse(),
+	})
+}
+
+// Update handles PUT /api/v1/:resource/:id
+func (h *Handler[T, P]) Update(c *gin.Context) {
+	id := c.Param("id")
+	version, ok := IfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var item T
+	if err := c.ShouldBindJSON(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Validate(&item); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Validation failed: " + err.Error(),
+		})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.service.Update(ctx, id, &item, version); err != nil {
+		h.RespondWriteError(c, err, "update")
+		return
+	}
+
+	updated, err := h.service.GetByID(ctx, id)
+	if err != nil {
+		h.RespondWriteError(c, err, "update")
+		return
+	}
+	SetETag(c, P(updated).ItemID(), P(updated).ItemVersion())
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": h.resource.title() + " updated successfully",
+		"data":    P(updated).ToResponse(),
+	})
+}
+
+// Patch handles PATCH /api/v1/:resource/:id
+func (h *Handler[T, P]) Patch(c *gin.Context) {
+	id := c.Param("id")
+	version, ok := IfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid request body: " + err.Error(),
+		})
+		return
+	}
+
+	item, err := h.service.Patch(c.Request.Context(), id, body, c.ContentType(), version)
+	if err != nil {
+		if respondPatchError(c, err) {
+			return
+		}
+		h.RespondWriteError(c, err, "update")
+		return
+	}
+
+	SetETag(c, P(item).ItemID(), P(item).ItemVersion())
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": h.resource.title() + " updated successfully",
+		"data":    P(item).ToResponse(),
+	})
+}
+
+// Delete handles DELETE /api/v1/:resource/:id
+func (h *Handler[T, P]) Delete(c *gin.Context) {
+	version, ok := IfMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), c.Param("id"), version); err != nil {
+		h.RespondWriteError(c, err, "delete")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": h.resource.title() + " deleted successfully",
+	})
+}
+
+// Bulk handles POST /api/v1/:resource/bulk
+func (h *Handler[T, P]) Bulk(c *gin.Context) {
+	runBulk(c, bulkActions[T]{
+		create: h.service.Create,
+		update: h.service.Update,
+		delete: h.service.Delete,
+		get:    h.service.GetByID,
+		respond: func(item *T) map[string]interface{} {
+			return P(item).ToResponse()
+		},
+	})
+}
+
+// Export handles GET /api/v1/:resource/export
+func (h *Handler[T, P]) Export(c *gin.Context) {
+	runExport(c, h.resource.slug(), h.filter(c), h.service.Each)
+}
+
+// Import handles POST /api/v1/:resource/import
+func (h *Handler[T, P]) Import(c *gin.Context) {
+	runImport(c, importActions[T]{
+		validate:   h.service.Validate,
+		create:     h.service.Create,
+		update:     h.service.Update,
+		find:       h.service.Find,
+		naturalKey: h.resource.NaturalKey,
+	})
+}
+
+// Reorder handles POST /api/v1/:resource/reorder
+func (h *Handler[T, P]) Reorder(c *gin.Context) {
+	runReorder(c, h.service.Reorder)
+}
+
+// RespondWriteError writes the response for an error of the service's
+// GetByID, Update, Patch or Delete: 412 for a stale version, 400 for a
+// malformed id, 404 for a missing item and otherwise a 500 saying the action
+// failed.
+func (h *Handler[T, P]) RespondWriteError(c *gin.Context, err error, action string) {
+	switch {
+	case errors.Is(err, repository.ErrVersionConflict):
+		RespondVersionConflict(c)
+	case errors.Is(err, primitive.ErrInvalidHex):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid id",
+		})
+	case errors.Is(err, ErrNotFound), errors.Is(err, mongo.ErrNoDocuments):
+		h.respondNotFound(c)
+	default:
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to " + action + " " + h.resource.Label,
+		})
+	}
+}
+
+func (h *Handler[T, P]) respondNotFound(c *gin.Context) {
+	c.JSON(http.StatusNotFound, gin.H{
+		"success": false,
+		"error":   h.resource.title() + " not found",
+	})
+}
+
+// respondPatchError writes the client error for a failed patch and reports
+// whether it did. Errors it does not recognise are left to the caller.
+func respondPatchError(c *gin.Context, err error) bool {
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.Is(err, ErrUnsupportedPatchMedia):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"success": false,
+			"error":   "Content-Type must be " + MergePatchContentType + " or " + JSONPatchContentType,
+		})
+	case errors.Is(err, ErrInvalidPatch):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   err.Error(),
+		})
+	case errors.As(err, &validationErrs):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Validation failed: " + validationErrs.Error(),
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+// expands reports whether the comma-separated expand query parameter names
+// the given expansion, as in ?expand=related.
+func expands(c *gin.Context, name string) bool {
+	for _, value := range strings.Split(c.Query("expand"), ",") {
+		if strings.TrimSpace(value) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addRelated sets data["related"] to the items linked to the item with the
+// given ID. It answers 500 itself and returns false when they cannot be
+// loaded. Related items change without a new version of the item, so a
+// response expanded with them is never served as 304.
+func addRelated(c *gin.Context, data map[string]interface{}, id primitive.ObjectID, related func(context.Context, primitive.ObjectID) ([]map[string]interface{}, error)) bool {
+	items, err := related(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"error":   "Failed to fetch related items",
+		})
+		return false
+	}
+	data["related"] = items
+	return true
+}
@@ -0,0 +1,353 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"naradai-backend/internal/repository"
+	"naradai-backend/internal/tabular"
+)
+
+// maxImportSize caps the size of an uploaded import file.
+const maxImportSize = 10 << 20
+
+// importSkipColumns are server-managed and never read from an import file,
+// so an export can be re-imported unchanged.
+var importSkipColumns = []string{"id", "version", "created_at", "updated_at"}
+
+// importActions binds a resource's service methods for runImport.
+type importActions[T any] struct {
+	validate   func(item *T) error
+	create     func(ctx context.Context, item *T) error
+	update     func(ctx context.Context, id string, item *T, version int64) error
+	find       func(ctx context.Context, filter bson.M, limit, offset int64) ([]T, int64, error)
+	naturalKey string // field matched against existing items when ?upsert=true
+}
+
+type importRow struct {
+	Row    int                  `json:"row"`
+	Action string               `json:"action"` // create, update or error
+	ID     string               `json:"id,omitempty"`
+	Errors []tabular.FieldError `json:"errors,omitempty"`
+}
+
+type importReport struct {
+	DryRun         bool        `json:"dry_run"`
+	Upsert         bool        `json:"upsert"`
+	NaturalKey     string      `json:"natural_key,omitempty"`
+	TotalRows      int         `json:"total_rows"`
+	Created        int         `json:"created"`
+	Updated        int         `json:"updated"`
+	Failed         int         `json:"failed"`
+	IgnoredColumns []string    `json:"ignored_columns,omitempty"`
+	Rows           []importRow `json:"rows"`
+}
+
+// runImport handles POST /api/v1/:resource/import. The file is read as CSV,
+// XLSX or a JSON array, every row is validated with the service's rules, and
+// the report lists the outcome of each row.
+//
+// Query parameters:
+//   - format=csv|xlsx|json overrides detection from the file name or Content-Type
+//   - dry_run=true validates and reports without writing anything
+//   - upsert=true updates items whose natural key already exists instead of creating duplicates,
+//     changing only the fields a row has a value for
+//   - partial=true writes the valid rows even when other rows fail; by default nothing is written if any row fails
+func runImport[T any](c *gin.Context, actions importActions[T]) {
+	ctx := c.Request.Context()
+	report := importReport{
+		DryRun: c.Query("dry_run") == "true",
+		Upsert: c.Query("upsert") == "true",
+	}
+	if report.Upsert {
+		report.NaturalKey = actions.naturalKey
+	}
+	partial := c.Query("partial") == "true"
+
+	items, rows, decoders, ignored, err := readImport[T](c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error":   "Invalid import file: " + err.Error(),
+		})
+		return
+	}
+	report.TotalRows = len(rows)
+	report.IgnoredColumns = ignored
+
+	// Validate every row and resolve natural keys before writing anything
+	itemType := reflect.TypeOf((*T)(nil)).Elem()
+	seenKeys := make(map[interface{}]int)
+	for i := range items {
+		row := &rows[i]
+		if report.Upsert {
+			key, _ := tabular.Lookup(&items[i], actions.naturalKey)
+			if other, duplicate := seenKeys[key]; duplicate {
+				row.Errors = append(row.Errors, tabular.FieldError{
+					Column:  actions.naturalKey,
+					Message: fmt.Sprintf("duplicate value %v, already used in row %d", key, other),
+				})
+			} else {
+				seenKeys[key] = row.Row
+			}
+
+			if len(row.Errors) == 0 {
+				existing, _, err := actions.find(ctx, bson.M{actions.naturalKey: key}, 1, 0)
+				if err != nil {
+					c.Error(err)
+					c.JSON(http.StatusInternalServerError, gin.H{
+						"success": false,
+						"error":   "Failed to look up existing items",
+					})
+					return
+				}
+				if len(existing) > 0 {
+					row.Action = "update"
+					id, _ := tabular.Lookup(&existing[0], "id")
+					row.ID, _ = id.(string)
+					// The row only changes the fields it has a value for;
+					// missing columns and blank cells keep the stored values
+					items[i] = existing[0]
+					row.Errors = decoders[i](&items[i])
+				}
+			}
+		}
+
+		if len(row.Errors) == 0 {
+			if err := actions.validate(&items[i]); err != nil {
+				row.Errors = validationFieldErrors(err, itemType)
+			}
+		}
+
+		if len(row.Errors) > 0 {
+			row.Action = "error"
+			report.Failed++
+		} else if row.Action == "" {
+			row.Action = "create"
+		}
+	}
+
+	if report.DryRun || (report.Failed > 0 && !partial) {
+		for _, row := range rows {
+			switch row.Action {
+			case "create":
+				report.Created++
+			case "update":
+				report.Updated++
+			}
+		}
+		report.Rows = rows
+
+		status := http.StatusOK
+		message := "Dry run completed, nothing was written"
+		if !report.DryRun {
+			status = http.StatusUnprocessableEntity
+			message = "Import rejected, fix the failed rows or retry with partial=true"
+		}
+		c.JSON(status, gin.H{
+			"success": report.Failed == 0,
+			"message": message,
+			"data":    report,
+		})
+		return
+	}
+
+	for i := range items {
+		row := &rows[i]
+		var err error
+		switch row.Action {
+		case "create":
+			if err = actions.create(ctx, &items[i]); err == nil {
+				id, _ := tabular.Lookup(&items[i], "id")
+				row.ID, _ = id.(string)
+				report.Created++
+			}
+		case "update":
+			if err = actions.update(ctx, row.ID, &items[i], repository.AnyVersion); err == nil {
+				report.Updated++
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "import row failed", "row", row.Row, "action", row.Action, "error", err)
+			row.Action = "error"
+			row.Errors = append(row.Errors, tabular.FieldError{Message: "failed to save row"})
+			report.Failed++
+		}
+	}
+	report.Rows = rows
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": report.Failed == 0,
+		"message": fmt.Sprintf("Imported %d rows (%d created, %d updated, %d failed)", report.Created+report.Updated, report.Created, report.Updated, report.Failed),
+		"data":    report,
+	})
+}
+
+// rowDecoder decodes one row of an import file onto dst. Fields the row has
+// no value for are left as they are.
+type rowDecoder[T any] func(dst *T) []tabular.FieldError
+
+// readImport decodes the uploaded file into items, with one report row per
+// item carrying its row number and any decoding errors. decoders decode the
+// same rows again, onto the existing item an upsert row updates.
+func readImport[T any](c *gin.Context) (items []T, rows []importRow, decoders []rowDecoder[T], ignored []string, err error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	var body io.Reader = c.Request.Body
+	filename := ""
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, nil, nil, nil, errors.New(`multipart upload must have a "file" field`)
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		defer file.Close()
+		body = file
+		filename = header.Filename
+	}
+
+	format, err := importFormat(c, filename)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	if format == tabular.JSON {
+		var elements []json.RawMessage
+		if err := json.NewDecoder(body).Decode(&elements); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("expected a JSON array of objects: %v", err)
+		}
+		items = make([]T, len(elements))
+		rows = make([]importRow, len(elements))
+		decoders = make([]rowDecoder[T], len(elements))
+		for i, element := range elements {
+			decoders[i] = func(dst *T) []tabular.FieldError {
+				decoder := json.NewDecoder(bytes.NewReader(element))
+				decoder.DisallowUnknownFields()
+				if err := decoder.Decode(dst); err != nil {
+					return []tabular.FieldError{{Message: err.Error()}}
+				}
+				return nil
+			}
+			rows[i].Row = i + 1
+			rows[i].Errors = decoders[i](&items[i])
+		}
+		return items, rows, decoders, nil, nil
+	}
+
+	var header []string
+	var records [][]string
+	if format == tabular.XLSX {
+		header, records, err = tabular.ReadXLSX(body, c.Query("sheet"))
+	} else {
+		header, records, err = tabular.ReadCSV(body)
+	}
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	decoder := tabular.NewDecoder(reflect.TypeOf((*T)(nil)), header, importSkipColumns...)
+	items = make([]T, 0, len(records))
+	rows = make([]importRow, 0, len(records))
+	decoders = make([]rowDecoder[T], 0, len(records))
+	for i, record := range records {
+		if isBlankRecord(record) {
+			continue
+		}
+		decode := func(dst *T) []tabular.FieldError {
+			return decoder.Decode(record, dst)
+		}
+		var item T
+		items = append(items, item)
+		decoders = append(decoders, decode)
+		rows = append(rows, importRow{
+			Row:    i + 2, // 1-based, after the header row
+			Errors: decode(&items[len(items)-1]),
+		})
+	}
+	return items, rows, decoders, decoder.Ignored(), nil
+}
+
+func importFormat(c *gin.Context, filename string) (tabular.Format, error) {
+	if name := c.Query("format"); name != "" {
+		return tabular.ParseFormat(name)
+	}
+	if ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."); ext != "" {
+		return tabular.ParseFormat(ext)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return tabular.CSV, nil
+	case tabular.XLSX.ContentType():
+		return tabular.XLSX, nil
+	case "application/json":
+		return tabular.JSON, nil
+	}
+	return "", errors.New("cannot tell the file format, pass ?format=csv|xlsx|json")
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// validationFieldErrors turns validator errors into per-column messages.
+func validationFieldErrors(err error, t reflect.Type) []tabular.FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return []tabular.FieldError{{Message: err.Error()}}
+	}
+
+	errs := make([]tabular.FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		// StructNamespace looks like "Risk.Indicators[0].Label"
+		parts := strings.Split(fe.StructNamespace(), ".")
+		field := fe.StructField()
+		if len(parts) > 1 {
+			field, _, _ = strings.Cut(parts[1], "[")
+		}
+		errs[i] = tabular.FieldError{
+			Column:  tabular.ColumnName(t, field),
+			Message: validationMessage(fe),
+		}
+	}
+	return errs
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + fe.Param()
+	case "min":
+		return "must be at least " + fe.Param()
+	case "max":
+		return "must be at most " + fe.Param()
+	default:
+		return fmt.Sprintf("failed %q validation", fe.Tag())
+	}
+}
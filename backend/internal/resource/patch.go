@@ -0,0 +1,119 @@
+package resource
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Content types accepted by the PATCH endpoints.
+const (
+	MergePatchContentType = "application/merge-patch+json" // RFC 7396
+	JSONPatchContentType  = "application/json-patch+json"  // RFC 6902
+)
+
+var (
+	ErrInvalidPatch          = errors.New("invalid patch document")
+	ErrUnsupportedPatchMedia = errors.New("unsupported patch content type")
+)
+
+// readOnlyFields are managed by the server and cannot be changed by a patch,
+// along with the fields a resource owns.
+var readOnlyFields = []string{"id", "version", "created_at", "updated_at"}
+
+// applyPatch applies patch to the JSON representation of current and decodes
+// the result into dst. Plain application/json bodies are treated as merge patches.
+func applyPatch(current, dst interface{}, patch []byte, contentType string, owned []string) error {
+	original, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var patched []byte
+	switch contentType {
+	case MergePatchContentType, "application/json", "":
+		if !json.Valid(patch) {
+			return fmt.Errorf("%w: malformed JSON", ErrInvalidPatch)
+		}
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case JSONPatchContentType:
+		ops, decodeErr := jsonpatch.DecodePatch(patch)
+		if decodeErr != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPatch, decodeErr)
+		}
+		patched, err = ops.Apply(original)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedPatchMedia, contentType)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	if err := checkReadOnly(original, patched, append(readOnlyFields, owned...)); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
+
+func checkReadOnly(original, patched []byte, readOnly []string) error {
+	var before, after map[string]json.RawMessage
+	if err := json.Unmarshal(original, &before); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(patched, &after); err != nil {
+		return fmt.Errorf("%w: patched document is not an object", ErrInvalidPatch)
+	}
+	for _, field := range readOnly {
+		if !bytes.Equal(before[field], after[field]) {
+			return fmt.Errorf("%w: field %q is read-only", ErrInvalidPatch, field)
+		}
+	}
+	return nil
+}
+
+// changedFields returns the top-level BSON fields of after that differ from
+// before, ready to be used in a $set. Server-managed fields are skipped.
+func changedFields(before, after interface{}, owned []string) (bson.M, error) {
+	beforeRaw, err := bson.Marshal(before)
+	if err != nil {
+		return nil, err
+	}
+	afterRaw, err := bson.Marshal(after)
+	if err != nil {
+		return nil, err
+	}
+
+	elements, err := bson.Raw(afterRaw).Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := bson.M{}
+	for _, element := range elements {
+		key := element.Key()
+		switch {
+		case key == "_id", key == "version", key == "created_at", key == "updated_at":
+			continue
+		case slices.Contains(owned, key):
+			continue
+		}
+		value := element.Value()
+		previous, lookupErr := bson.Raw(beforeRaw).LookupErr(key)
+		if lookupErr == nil && previous.Equal(value) {
+			continue
+		}
+		fields[key] = value
+	}
+	return fields, nil
+}
@@ -0,0 +1,280 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// ListSort is the list order of every resource: the user-defined order, then
+// newest first.
+var ListSort = bson.D{{Key: "order", Value: 1}, {Key: "created_at", Value: -1}}
+
+// illegalOperation is the server error code for transactions on a standalone mongod.
+const illegalOperation = 20
+
+// Repository stores the items of a resource in its MongoDB collection. It is
+// a repository.OrderedStore.
+type Repository[T any, P Model[T]] struct {
+	resource   *Resource[T, P]
+	collection *mongo.Collection
+}
+
+func NewRepository[T any, P Model[T]](db *mongo.Database, r *Resource[T, P]) *Repository[T, P] {
+	return &Repository[T, P]{
+		resource:   r,
+		collection: db.Collection(r.Name),
+	}
+}
+
+// Create inserts the item with a new ID, version 1 and both timestamps, and
+// reads them back into item.
+func (r *Repository[T, P]) Create(ctx context.Context, item *T) error {
+	if r.resource.Defaults != nil {
+		r.resource.Defaults(item, true)
+	}
+	doc, err := toDoc(item)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	doc["_id"] = primitive.NewObjectID()
+	doc["version"] = int64(1)
+	doc["created_at"] = now
+	doc["updated_at"] = now
+	if r.resource.Active {
+		doc["is_active"] = true
+	}
+	if r.resource.Linked {
+		doc["flagged_reason"] = ""
+		doc["flagged_at"] = nil
+	}
+
+	if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+		return err
+	}
+	return fromDoc(doc, item)
+}
+
+func (r *Repository[T, P]) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]T, int64, error) {
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetLimit(limit).
+		SetSkip(offset).
+		SetSort(ListSort)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []T
+	if err = cursor.All(ctx, &items); err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+// Each calls fn for every item matching filter in list order, streaming
+// from the cursor instead of loading all documents at once.
+func (r *Repository[T, P]) Each(ctx context.Context, filter bson.M, fn func(*T) error) error {
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(ListSort))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var item T
+		if err := cursor.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(&item); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *Repository[T, P]) GetByID(ctx context.Context, id string) (*T, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var item T
+	err = r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&item)
+	if err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// Update replaces every field of the item except its ID, creation time,
+// version and the fields the server owns, and bumps its version.
+func (r *Repository[T, P]) Update(ctx context.Context, id string, item *T, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	if r.resource.Defaults != nil {
+		r.resource.Defaults(item, false)
+	}
+	fields, err := toDoc(item)
+	if err != nil {
+		return err
+	}
+	for _, field := range append([]string{"_id", "created_at", "version"}, r.resource.OwnedFields()...) {
+		delete(fields, field)
+	}
+	fields["updated_at"] = time.Now()
+	update := bson.M{
+		"$set": fields,
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, repository.VersionFilter(objectID, version), update)
+	if err != nil {
+		return err
+	}
+	return repository.CheckMatched(ctx, r.collection, objectID, version, result.MatchedCount)
+}
+
+// Patch sets only the given fields on the item and bumps its version.
+func (r *Repository[T, P]) Patch(ctx context.Context, id string, fields bson.M, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	fields["updated_at"] = time.Now()
+	update := bson.M{
+		"$set": fields,
+		"$inc": bson.M{"version": 1},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, repository.VersionFilter(objectID, version), update)
+	if err != nil {
+		return err
+	}
+	return repository.CheckMatched(ctx, r.collection, objectID, version, result.MatchedCount)
+}
+
+func (r *Repository[T, P]) Delete(ctx context.Context, id string, version int64) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, repository.VersionFilter(objectID, version))
+	if err != nil {
+		return err
+	}
+	return repository.CheckMatched(ctx, r.collection, objectID, version, result.DeletedCount)
+}
+
+// Reorder sets the order field of every listed item to its position in ids,
+// all inside a single transaction.
+func (r *Repository[T, P]) Reorder(ctx context.Context, ids []string) error {
+	objectIDs := make([]primitive.ObjectID, len(ids))
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	for i, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return fmt.Errorf("%w: %q is not a valid id", repository.ErrInvalidReorder, id)
+		}
+		if seen[objectID] {
+			return fmt.Errorf("%w: %q is listed more than once", repository.ErrInvalidReorder, id)
+		}
+		seen[objectID] = true
+		objectIDs[i] = objectID
+	}
+
+	return r.Transaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		writes := make([]mongo.WriteModel, len(objectIDs))
+		for i, objectID := range objectIDs {
+			writes[i] = mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": objectID}).
+				SetUpdate(bson.M{
+					"$set": bson.M{"order": i, "updated_at": now},
+					"$inc": bson.M{"version": 1},
+				})
+		}
+
+		result, err := r.collection.BulkWrite(ctx, writes)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount != int64(len(writes)) {
+			return fmt.Errorf("%w: %d of %d ids do not exist", repository.ErrInvalidReorder, int64(len(writes))-result.MatchedCount, len(writes))
+		}
+		return nil
+	})
+}
+
+// Transaction runs fn in a transaction on a session of the database's
+// client, so the writes of every repository fn passes ctx to are part of it.
+func (r *Repository[T, P]) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	session, err := r.collection.Database().Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && serverErr.HasErrorCode(illegalOperation) {
+		return repository.ErrTransactionsUnsupported
+	}
+	return err
+}
+
+// toDoc encodes item as the document stored for it.
+func toDoc(item interface{}) (bson.M, error) {
+	raw, err := bson.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// fromDoc replaces *item with doc decoded, so the caller sees the fields the
+// repository filled in.
+func fromDoc[T any](doc bson.M, item *T) error {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	var stored T
+	if err := bson.Unmarshal(raw, &stored); err != nil {
+		return err
+	}
+	*item = stored
+	return nil
+}
+
+var _ repository.OrderedStore[models.Risk] = (*Repository[models.Risk, *models.Risk])(nil)
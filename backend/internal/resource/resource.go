@@ -0,0 +1,90 @@
+// Package resource is the stack every dashboard resource is built on. A
+// Resource describes one model; NewRepository, NewService and NewHandler turn
+// it into its MongoDB repository, its service and its HTTP handler, and the
+// handler registers the routes all resources share: list, get, create,
+// replace, patch, delete, bulk, export, import and reorder.
+//
+// What differs between resources is plugged in: defaults and filters on the
+// Resource, validation and side effects as the service's Hooks, and the
+// rendering of items on the Handler. Routes particular to one resource are
+// registered next to the shared ones by a handler embedding Handler.
+package resource
+
+import (
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"naradai-backend/internal/models"
+)
+
+// Model is the constraint on the item types of a resource: P is the pointer
+// type of the model struct T, which carries the ID and version of the item.
+type Model[T any] interface {
+	*T
+	ItemID() primitive.ObjectID
+	ItemVersion() int64
+	ToResponse() map[string]interface{}
+}
+
+// Resource describes a dashboard resource.
+type Resource[T any, P Model[T]] struct {
+	// Name is the collection of the items and the resource type relations
+	// and evidence refer to them by, e.g. "priority_actions". Routes and
+	// export files use it with dashes.
+	Name string
+	// Label names one item in messages, e.g. "priority action".
+	Label string
+	// NaturalKey is the field an import with ?upsert=true matches existing
+	// items on.
+	NaturalKey string
+	// Filters are the fields GetAll and Export filter on when a query
+	// parameter of the same name is given.
+	Filters []string
+	// Active resources have an is_active flag, which is set on create and
+	// can be filtered on with ?is_active=true|false.
+	Active bool
+	// Owned are the fields the server computes, which replacing or patching
+	// an item leaves alone.
+	Owned []string
+	// Linked resources have items that relations can link. Deleting an
+	// item flags the items that depended on it with flagged_reason and
+	// flagged_at, which the server owns and ?flagged=true|false filters on.
+	Linked bool
+	// Defaults fills in the fields a client left empty, before an item is
+	// created or replaced.
+	Defaults func(item *T, creating bool)
+}
+
+// Type is the resource type relations and evidence refer to the items by.
+func (r *Resource[T, P]) Type() models.ResourceType {
+	return models.ResourceType(r.Name)
+}
+
+// OwnedFields are the fields replacing or patching an item leaves alone:
+// Owned, and the flag of a linked item.
+func (r *Resource[T, P]) OwnedFields() []string {
+	if !r.Linked {
+		return r.Owned
+	}
+	return append(append([]string{}, r.Owned...), "flagged_reason", "flagged_at")
+}
+
+// Path is the route of the resource under /api/v1, e.g. "/priority-actions".
+func (r *Resource[T, P]) Path() string {
+	return "/" + r.slug()
+}
+
+// slug is the name used in routes and export files, e.g. "priority-actions".
+func (r *Resource[T, P]) slug() string {
+	return strings.ReplaceAll(r.Name, "_", "-")
+}
+
+// plural names the items in messages, e.g. "priority actions".
+func (r *Resource[T, P]) plural() string {
+	return strings.ReplaceAll(r.Name, "_", " ")
+}
+
+// title is the label starting a sentence, e.g. "Priority action".
+func (r *Resource[T, P]) title() string {
+	return strings.ToUpper(r.Label[:1]) + r.Label[1:]
+}
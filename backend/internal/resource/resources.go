@@ -0,0 +1,80 @@
+package resource
+
+import "naradai-backend/internal/models"
+
+// The resources of the dashboard.
+var (
+	PriorityActions = &Resource[models.PriorityAction, *models.PriorityAction]{
+		Name:       "priority_actions",
+		Label:      "priority action",
+		NaturalKey: "title",
+		Filters:    []string{"priority", "status"},
+		Linked:     true,
+		Defaults: func(action *models.PriorityAction, creating bool) {
+			if creating && action.Status == "" {
+				action.Status = models.StatusNotStarted
+			}
+		},
+	}
+
+	DashboardStats = &Resource[models.DashboardStat, *models.DashboardStat]{
+		Name:       "dashboard_stats",
+		Label:      "dashboard stat",
+		NaturalKey: "label",
+		Active:     true,
+		Owned:      []string{"numeric_value"},
+	}
+
+	SentimentTrends = &Resource[models.SentimentTrend, *models.SentimentTrend]{
+		Name:       "sentiment_trends",
+		Label:      "sentiment trend",
+		NaturalKey: "title",
+		Active:     true,
+	}
+
+	DiscussionTopics = &Resource[models.DiscussionTopic, *models.DiscussionTopic]{
+		Name:       "discussion_topics",
+		Label:      "discussion topic",
+		NaturalKey: "name",
+		Active:     true,
+		Linked:     true,
+	}
+
+	CompetitiveAnalyses = &Resource[models.CompetitiveAnalysis, *models.CompetitiveAnalysis]{
+		Name:       "competitive_analyses",
+		Label:      "competitive analysis",
+		NaturalKey: "name",
+		Active:     true,
+	}
+
+	ConversationClusters = &Resource[models.ConversationCluster, *models.ConversationCluster]{
+		Name:       "conversation_clusters",
+		Label:      "conversation cluster",
+		NaturalKey: "theme",
+		Active:     true,
+		Linked:     true,
+		Defaults: func(cluster *models.ConversationCluster, creating bool) {
+			if cluster.Trend == "" {
+				cluster.Trend = "stable"
+			}
+		},
+	}
+
+	Risks = &Resource[models.Risk, *models.Risk]{
+		Name:       "risks",
+		Label:      "risk",
+		NaturalKey: "title",
+		Filters:    []string{"severity"},
+		Active:     true,
+		Linked:     true,
+	}
+
+	Opportunities = &Resource[models.Opportunity, *models.Opportunity]{
+		Name:       "opportunities",
+		Label:      "opportunity",
+		NaturalKey: "title",
+		Filters:    []string{"potential"},
+		Active:     true,
+		Linked:     true,
+	}
+)
@@ -0,0 +1,348 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// tracer starts the spans of service methods, named after the model as the
+// hand-written services name theirs, such as RiskService.Create.
+var tracer = otel.Tracer("naradai-backend/internal/resource")
+
+// ErrNotFound is wrapped by the errors returned for a missing item, whose
+// message names the resource, as in "risk not found".
+var ErrNotFound = errors.New("not found")
+
+// Hooks are the behaviour of a resource's service beyond storing its items.
+// Every hook is optional.
+type Hooks[T any] struct {
+	// Validate checks what the validate tags of the model cannot express.
+	Validate func(item *T) error
+	// Prepare adjusts a valid item before it is created, replaced or
+	// patched.
+	Prepare func(item *T)
+	// Loaded completes an item read from the store before it is returned.
+	Loaded func(ctx context.Context, item *T)
+	// Saved runs after an item is created or, with created false, replaced
+	// or patched, on the item as stored.
+	Saved func(ctx context.Context, item *T, created bool) error
+	// Deleted cascades the deletion of an item, on the item as it was. It
+	// runs in the transaction deleting the item, or before the item is
+	// deleted when MongoDB cannot run one; an error keeps the item.
+	Deleted func(ctx context.Context, item *T) error
+	// Related lists the items linked to an item, for ?expand=related.
+	Related func(ctx context.Context, ref models.ResourceRef) ([]map[string]interface{}, error)
+}
+
+// Service validates the items of a resource and runs its hooks around the
+// store.
+type Service[T any, P Model[T]] struct {
+	resource  *Resource[T, P]
+	repo      repository.OrderedStore[T]
+	hooks     Hooks[T]
+	validator *validator.Validate
+	// spanPrefix names the spans, e.g. "RiskService."
+	spanPrefix string
+	notFound   error
+}
+
+func NewService[T any, P Model[T]](r *Resource[T, P], repo repository.OrderedStore[T], hooks Hooks[T]) *Service[T, P] {
+	return &Service[T, P]{
+		resource:   r,
+		repo:       repo,
+		hooks:      hooks,
+		validator:  validator.New(),
+		spanPrefix: reflect.TypeOf((*T)(nil)).Elem().Name() + "Service.",
+		notFound:   fmt.Errorf("%s %w", r.Label, ErrNotFound),
+	}
+}
+
+// Resource is the resource the service manages.
+func (s *Service[T, P]) Resource() *Resource[T, P] {
+	return s.resource
+}
+
+func (s *Service[T, P]) Validate(item *T) error {
+	if err := s.validator.Struct(item); err != nil {
+		return err
+	}
+	if s.hooks.Validate != nil {
+		return s.hooks.Validate(item)
+	}
+	return nil
+}
+
+// validated runs Validate in a span of its own, so a trace shows how long
+// validation took and whether it rejected the input.
+func (s *Service[T, P]) validated(ctx context.Context, item *T) error {
+	_, span := tracer.Start(ctx, "validate")
+	defer span.End()
+	if err := s.Validate(item); err != nil {
+		span.SetStatus(codes.Error, "validation failed")
+		return err
+	}
+	return nil
+}
+
+// prepare runs the Prepare hook on a valid item.
+func (s *Service[T, P]) prepare(item *T) {
+	if s.hooks.Prepare != nil {
+		s.hooks.Prepare(item)
+	}
+}
+
+func (s *Service[T, P]) Create(ctx context.Context, item *T) error {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Create")
+	defer span.End()
+
+	if err := s.validated(ctx, item); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	s.prepare(item)
+	if err := s.repo.Create(ctx, item); err != nil {
+		return err
+	}
+	if s.hooks.Saved != nil {
+		return s.hooks.Saved(ctx, item, true)
+	}
+	return nil
+}
+
+func (s *Service[T, P]) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]T, int64, error) {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"GetAll")
+	defer span.End()
+
+	items, total, err := s.repo.GetAll(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if s.hooks.Loaded != nil {
+		for i := range items {
+			s.hooks.Loaded(ctx, &items[i])
+		}
+	}
+	return items, total, nil
+}
+
+// Find lists the items matching filter as stored, without the Loaded hook,
+// for callers that write them back, such as an import's upsert.
+func (s *Service[T, P]) Find(ctx context.Context, filter bson.M, limit, offset int64) ([]T, int64, error) {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Find")
+	defer span.End()
+
+	return s.repo.GetAll(ctx, filter, limit, offset)
+}
+
+func (s *Service[T, P]) Each(ctx context.Context, filter bson.M, fn func(*T) error) error {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Each")
+	defer span.End()
+
+	if s.hooks.Loaded == nil {
+		return s.repo.Each(ctx, filter, fn)
+	}
+	return s.repo.Each(ctx, filter, func(item *T) error {
+		s.hooks.Loaded(ctx, item)
+		return fn(item)
+	})
+}
+
+func (s *Service[T, P]) GetByID(ctx context.Context, id string) (*T, error) {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"GetByID")
+	defer span.End()
+
+	item, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if s.hooks.Loaded != nil {
+		s.hooks.Loaded(ctx, item)
+	}
+	return item, nil
+}
+
+func (s *Service[T, P]) Update(ctx context.Context, id string, item *T, version int64) error {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Update")
+	defer span.End()
+
+	if err := s.validated(ctx, item); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	s.prepare(item)
+
+	if _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, id, item, version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return s.notFound
+		}
+		return err
+	}
+	return s.saved(ctx, id)
+}
+
+// patchAttempts bounds how often Patch reapplies a patch sent without a
+// version when another write got in between reading and writing the item.
+const patchAttempts = 3
+
+// Patch applies a merge patch (RFC 7396) or JSON Patch (RFC 6902) to the item,
+// validates the resulting document and writes only the fields that changed.
+// A version other than AnyVersion must match the stored version. Without one
+// the patch is written against the version it was applied to, and applied
+// again to the new item when another write got in between.
+func (s *Service[T, P]) Patch(ctx context.Context, id string, patch []byte, contentType string, version int64) (*T, error) {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Patch")
+	defer span.End()
+
+	for attempt := 1; ; attempt++ {
+		item, err := s.patch(ctx, id, patch, contentType, version)
+		if err != repository.ErrVersionConflict || version != repository.AnyVersion || attempt == patchAttempts {
+			return item, err
+		}
+	}
+}
+
+func (s *Service[T, P]) patch(ctx context.Context, id string, patch []byte, contentType string, version int64) (*T, error) {
+	existing, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != repository.AnyVersion && P(existing).ItemVersion() != version {
+		return nil, repository.ErrVersionConflict
+	}
+
+	var item T
+	if err := applyPatch(existing, &item, patch, contentType, s.resource.OwnedFields()); err != nil {
+		return nil, err
+	}
+	if err := s.validated(ctx, &item); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	s.prepare(&item)
+
+	fields, err := changedFields(existing, &item, s.resource.OwnedFields())
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return existing, nil
+	}
+	if err := s.repo.Patch(ctx, id, fields, P(existing).ItemVersion()); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, s.notFound
+		}
+		return nil, err
+	}
+	if err := s.saved(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *Service[T, P]) Delete(ctx context.Context, id string, version int64) error {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Delete")
+	defer span.End()
+
+	existing, err := s.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if s.hooks.Deleted == nil {
+		return s.remove(ctx, id, version)
+	}
+
+	// The item goes together with what its deletion cascades to: in one
+	// transaction when MongoDB runs as a replica set, otherwise by cascading
+	// first and deleting the item only when that succeeded.
+	if tx, ok := s.repo.(repository.Transactor); ok {
+		err := tx.Transaction(ctx, func(ctx context.Context) error {
+			if err := s.remove(ctx, id, version); err != nil {
+				return err
+			}
+			return s.hooks.Deleted(ctx, existing)
+		})
+		if !errors.Is(err, repository.ErrTransactionsUnsupported) {
+			return err
+		}
+	}
+	if version != repository.AnyVersion && P(existing).ItemVersion() != version {
+		return repository.ErrVersionConflict
+	}
+	if err := s.hooks.Deleted(ctx, existing); err != nil {
+		return err
+	}
+	return s.remove(ctx, id, version)
+}
+
+// remove deletes the stored item, reporting a missing one as the resource's
+// not found error.
+func (s *Service[T, P]) remove(ctx context.Context, id string, version int64) error {
+	if err := s.repo.Delete(ctx, id, version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return s.notFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *Service[T, P]) Reorder(ctx context.Context, ids []string) error {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Reorder")
+	defer span.End()
+
+	return s.repo.Reorder(ctx, ids)
+}
+
+// HasRelated reports whether items of the resource can be linked to others.
+func (s *Service[T, P]) HasRelated() bool {
+	return s.hooks.Related != nil
+}
+
+// Related returns the items linked to the item with the given ID, as their
+// responses.
+func (s *Service[T, P]) Related(ctx context.Context, id primitive.ObjectID) ([]map[string]interface{}, error) {
+	ctx, span := tracer.Start(ctx, s.spanPrefix+"Related")
+	defer span.End()
+
+	if s.hooks.Related == nil {
+		return []map[string]interface{}{}, nil
+	}
+	return s.hooks.Related(ctx, models.ResourceRef{Type: s.resource.Type(), ID: id})
+}
+
+// get reads the stored item, reporting a missing one as the resource's not
+// found error.
+func (s *Service[T, P]) get(ctx context.Context, id string) (*T, error) {
+	item, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, s.notFound
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// saved runs the Saved hook on the item as stored after a replace or patch.
+func (s *Service[T, P]) saved(ctx context.Context, id string) error {
+	if s.hooks.Saved == nil {
+		return nil
+	}
+	stored, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.hooks.Saved(ctx, stored, false)
+}
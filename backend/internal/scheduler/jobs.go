@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// job is a fixed background task registered with AddJob. Its next run time
+// is kept in the scheduler_jobs collection so that a run is claimed once
+// across replicas and survives restarts.
+type job struct {
+	name     string
+	schedule cron.Schedule
+	run      func(ctx context.Context) error
+}
+
+// AddJob registers run to fire on the standard cron expression spec (UTC).
+// Jobs must be added before Start.
+func (s *Scheduler) AddJob(name, spec string, run func(ctx context.Context) error) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	s.jobs = append(s.jobs, job{name: name, schedule: schedule, run: run})
+	return nil
+}
+
+// runJobs starts every registered job that is due, claiming each run by
+// moving its next_run_at forward.
+func (s *Scheduler) runJobs(ctx context.Context, now time.Time) {
+	for _, j := range s.jobs {
+		claimed, err := s.claimJob(ctx, j, now)
+		if err != nil {
+			slog.ErrorContext(ctx, "scheduler: failed to claim job", "job", j.name, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		j := j
+		s.runs.Add(1)
+		go func() {
+			defer s.runs.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+			defer cancel()
+			if err := j.run(ctx); err != nil {
+				slog.ErrorContext(ctx, "scheduler: job failed", "job", j.name, "error", err)
+			}
+		}()
+	}
+}
+
+func (s *Scheduler) claimJob(ctx context.Context, j job, now time.Time) (bool, error) {
+	next := j.schedule.Next(now.UTC())
+
+	// The first time a job is seen it is only scheduled, not run
+	_, err := s.jobState.UpdateOne(ctx,
+		bson.M{"_id": j.name},
+		bson.M{"$setOnInsert": bson.M{"next_run_at": next}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	result, err := s.jobState.UpdateOne(ctx,
+		bson.M{"_id": j.name, "next_run_at": bson.M{"$lte": now}},
+		bson.M{"$set": bson.M{"next_run_at": next, "last_run_at": now}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Lock is a lease held in the scheduler_locks collection. Only the replica
+// holding the lease runs scheduled jobs; when it stops renewing, another
+// replica takes over once the lease expires.
+type Lock struct {
+	collection *mongo.Collection
+	name       string
+	owner      string
+	ttl        time.Duration
+}
+
+func NewLock(db *mongo.Database, name, owner string, ttl time.Duration) *Lock {
+	return &Lock{
+		collection: db.Collection("scheduler_locks"),
+		name:       name,
+		owner:      owner,
+		ttl:        ttl,
+	}
+}
+
+// Acquire takes or renews the lease. It reports false while another owner
+// holds an unexpired lease.
+func (l *Lock) Acquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+	filter := bson.M{
+		"_id": l.name,
+		"$or": bson.A{
+			bson.M{"owner": l.owner},
+			bson.M{"expires_at": bson.M{"$lte": now}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"owner":       l.owner,
+		"acquired_at": now,
+		"expires_at":  now.Add(l.ttl),
+	}}
+
+	// With upsert, a missing lock document is created; a lock held by someone
+	// else fails the filter, and the insert then collides on _id
+	_, err := l.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Release gives up the lease if this owner holds it.
+func (l *Lock) Release(ctx context.Context) error {
+	_, err := l.collection.DeleteOne(ctx, bson.M{"_id": l.name, "owner": l.owner})
+	return err
+}
@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/mailer"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/report"
+	"naradai-backend/internal/service"
+)
+
+// runTimeout bounds generating and sending a single report.
+const runTimeout = 5 * time.Minute
+
+// Scheduler fires due report schedules and background jobs inside the
+// server process. Every replica runs one, but only the holder of the leader
+// lock polls for due work, and each run is claimed atomically before it
+// executes.
+type Scheduler struct {
+	schedules *service.ReportScheduleService
+	reports   *report.Generator
+	mailer    *mailer.Mailer
+	lock      *Lock
+	jobState  *mongo.Collection
+	jobs      []job
+	interval  time.Duration
+	runs      sync.WaitGroup
+	// lastTick is when the poll loop last ran, in Unix nanoseconds
+	lastTick atomic.Int64
+}
+
+func New(db *mongo.Database, schedules *service.ReportScheduleService, reports *report.Generator, mailer *mailer.Mailer, lock *Lock, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		schedules: schedules,
+		reports:   reports,
+		mailer:    mailer,
+		lock:      lock,
+		jobState:  db.Collection("scheduler_jobs"),
+		interval:  interval,
+	}
+}
+
+// Start polls for due schedules every interval until ctx is cancelled, then
+// releases the leader lock.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.tick(ctx)
+			select {
+			case <-ctx.Done():
+				releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				if err := s.lock.Release(releaseCtx); err != nil {
+					slog.Error("scheduler: failed to release leader lock", "error", err)
+				}
+				cancel()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Wait blocks until the runs in progress have finished.
+func (s *Scheduler) Wait() {
+	s.runs.Wait()
+}
+
+// Check reports whether the poll loop is running, failing when it has not
+// started or has missed more than three intervals, as when a tick is stuck
+// on the database.
+func (s *Scheduler) Check(ctx context.Context) error {
+	last := s.lastTick.Load()
+	if last == 0 {
+		return fmt.Errorf("scheduler has not started")
+	}
+	if since := time.Since(time.Unix(0, last)); since > 3*s.interval {
+		return fmt.Errorf("scheduler last polled %s ago", since.Round(time.Second))
+	}
+	return nil
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	s.lastTick.Store(time.Now().UnixNano())
+	leader, err := s.lock.Acquire(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "scheduler: failed to acquire leader lock", "error", err)
+		}
+		return
+	}
+	if !leader {
+		return
+	}
+
+	now := time.Now()
+	s.runJobs(ctx, now)
+
+	due, err := s.schedules.Due(ctx, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "scheduler: failed to load due schedules", "error", err)
+		return
+	}
+	for i := range due {
+		schedule := &due[i]
+		claimed, err := s.schedules.Claim(ctx, schedule, now)
+		if err != nil {
+			slog.ErrorContext(ctx, "scheduler: failed to claim schedule", "schedule_id", schedule.ID.Hex(), "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		run, err := s.schedules.StartRun(ctx, schedule, models.TriggerSchedule)
+		if err != nil {
+			slog.ErrorContext(ctx, "scheduler: failed to record run", "schedule_id", schedule.ID.Hex(), "error", err)
+			continue
+		}
+		s.execute(schedule, run)
+	}
+}
+
+// RunNow starts a run of the schedule outside its cron timing and returns
+// the run record while the report is generated and sent in the background.
+func (s *Scheduler) RunNow(ctx context.Context, id string) (*models.ReportRun, error) {
+	schedule, err := s.schedules.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.schedules.StartRun(ctx, schedule, models.TriggerManual)
+	if err != nil {
+		return nil, err
+	}
+	s.execute(schedule, run)
+	return run, nil
+}
+
+// execute generates and sends the report in the background. The run record
+// passed in is not modified; the outcome is written to the database.
+func (s *Scheduler) execute(schedule *models.ReportSchedule, run *models.ReportRun) {
+	result := *run
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), runTimeout)
+		defer cancel()
+
+		if err := s.deliver(ctx, schedule, &result); err != nil {
+			result.Status = models.ReportRunFailed
+			result.Error = err.Error()
+			slog.ErrorContext(ctx, "scheduler: report schedule failed", "schedule_id", schedule.ID.Hex(), "run_id", result.ID.Hex(), "error", err)
+		} else {
+			result.Status = models.ReportRunSucceeded
+		}
+		if err := s.schedules.FinishRun(ctx, &result); err != nil {
+			slog.ErrorContext(ctx, "scheduler: failed to record run outcome", "run_id", result.ID.Hex(), "error", err)
+		}
+	}()
+}
+
+func (s *Scheduler) deliver(ctx context.Context, schedule *models.ReportSchedule, run *models.ReportRun) error {
+	if !s.mailer.Configured() {
+		return mailer.ErrNotConfigured
+	}
+
+	attachment, err := s.reports.Generate(ctx, schedule.Format, schedule.Period, schedule.Resources)
+	if err != nil {
+		return fmt.Errorf("generate report: %w", err)
+	}
+	run.Filename = attachment.Filename
+	run.Size = len(attachment.Data)
+
+	subject := fmt.Sprintf("%s - %s", schedule.Name, time.Now().Format("2 January 2006"))
+	body := fmt.Sprintf("Attached is the %q report (%s).\n\nThis email is sent automatically; reply to your account manager with any questions.\n",
+		schedule.Name, attachment.Filename)
+	if err := s.mailer.Send(schedule.Recipients, subject, body, mailer.Attachment{
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Data:        attachment.Data,
+	}); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"naradai-backend/internal/models"
+)
+
+// Fixtures is the content of a dashboard, keyed by collection name. Fields
+// use the JSON names of the API, so a fixture item looks like a request body.
+type Fixtures struct {
+	PriorityActions      []models.PriorityAction      `json:"priority_actions"`
+	DashboardStats       []models.DashboardStat       `json:"dashboard_stats"`
+	SentimentTrends      []models.SentimentTrend      `json:"sentiment_trends"`
+	DiscussionTopics     []models.DiscussionTopic     `json:"discussion_topics"`
+	CompetitiveAnalyses  []models.CompetitiveAnalysis `json:"competitive_analyses"`
+	ConversationClusters []models.ConversationCluster `json:"conversation_clusters"`
+	Risks                []models.Risk                `json:"risks"`
+	Opportunities        []models.Opportunity         `json:"opportunities"`
+}
+
+// Append adds the items of other after those of f.
+func (f *Fixtures) Append(other *Fixtures) {
+	f.PriorityActions = append(f.PriorityActions, other.PriorityActions...)
+	f.DashboardStats = append(f.DashboardStats, other.DashboardStats...)
+	f.SentimentTrends = append(f.SentimentTrends, other.SentimentTrends...)
+	f.DiscussionTopics = append(f.DiscussionTopics, other.DiscussionTopics...)
+	f.CompetitiveAnalyses = append(f.CompetitiveAnalyses, other.CompetitiveAnalyses...)
+	f.ConversationClusters = append(f.ConversationClusters, other.ConversationClusters...)
+	f.Risks = append(f.Risks, other.Risks...)
+	f.Opportunities = append(f.Opportunities, other.Opportunities...)
+}
+
+// ReadFile reads fixtures from a .json, .yaml or .yml file. Unknown keys are
+// rejected so a misspelled field does not silently load as empty.
+func ReadFile(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+	case ".yaml", ".yml":
+		// Decode generically and re-encode as JSON, so YAML fixtures use the
+		// same field names as JSON ones
+		var value interface{}
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = json.Marshal(jsonValue(value)); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported fixture file type %q (use .json, .yaml or .yml)", path, filepath.Ext(path))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var fixtures Fixtures
+	if err := decoder.Decode(&fixtures); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &fixtures, nil
+}
+
+// jsonValue converts what YAML decodes into something JSON can encode: keys
+// become strings and unquoted dates keep the YYYY-MM-DD form trend data uses.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = jsonValue(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = jsonValue(item)
+		}
+		return v
+	case time.Time:
+		if v.Equal(v.Truncate(24 * time.Hour)) {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,418 @@
+package seed
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"naradai-backend/internal/models"
+)
+
+// Generate returns synthetic dashboard content with size items per
+// collection (dashboard stats are capped at the number of KPI templates).
+// The same seed always yields the same items; trend data ends on the day of
+// end, so only the dates move with it.
+func Generate(seed int64, size int, end time.Time) *Fixtures {
+	g := &generator{rand: rand.New(rand.NewSource(seed))}
+	return &Fixtures{
+		PriorityActions:      g.priorityActions(size),
+		DashboardStats:       g.dashboardStats(size),
+		SentimentTrends:      g.sentimentTrends(size, end),
+		DiscussionTopics:     g.discussionTopics(size),
+		CompetitiveAnalyses:  g.competitiveAnalyses(size),
+		ConversationClusters: g.conversationClusters(size),
+		Risks:                g.risks(size),
+		Opportunities:        g.opportunities(size),
+	}
+}
+
+type generator struct {
+	rand *rand.Rand
+}
+
+// cycle returns size indexes into a pool of n items, shuffling the pool for
+// every round, so no item repeats until all of them have been used.
+func (g *generator) cycle(n, size int) []int {
+	indexes := make([]int, size)
+	var perm []int
+	for i := range indexes {
+		if i%n == 0 {
+			perm = g.rand.Perm(n)
+		}
+		indexes[i] = perm[i%n]
+	}
+	return indexes
+}
+
+// names returns size names drawn from pool, numbering the second and later
+// rounds once the pool is used up.
+func (g *generator) names(pool []string, size int) []string {
+	names := make([]string, size)
+	for i, j := range g.cycle(len(pool), size) {
+		names[i] = pool[j]
+		if round := i / len(pool); round > 0 {
+			names[i] = fmt.Sprintf("%s (%d)", names[i], round+1)
+		}
+	}
+	return names
+}
+
+func pick[T any](g *generator, values ...T) T {
+	return values[g.rand.Intn(len(values))]
+}
+
+// between returns a uniform value in [min, max] rounded to decimals places.
+func (g *generator) between(min, max float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round((min+g.rand.Float64()*(max-min))*scale) / scale
+}
+
+// sample returns n distinct values from pool.
+func (g *generator) sample(pool []string, n int) []string {
+	if n > len(pool) {
+		n = len(pool)
+	}
+	values := make([]string, n)
+	for i, j := range g.rand.Perm(len(pool))[:n] {
+		values[i] = pool[j]
+	}
+	return values
+}
+
+var (
+	products = []string{"Kopi Susu Gula Aren", "Paket Hemat Keluarga", "Aplikasi Mobile", "Program Loyalty", "Layanan Pesan Antar", "Kemasan Baru", "Menu Musiman", "Kartu Member"}
+	channels = []string{"Twitter/X", "Instagram", "TikTok", "Facebook", "YouTube", "forum online", "portal berita"}
+	regions  = []string{"Jabodetabek", "Surabaya", "Bandung", "Medan", "Makassar", "Yogyakarta", "Bali"}
+	keywords = []string{"harga", "promo", "rasa", "pelayanan", "antrean", "aplikasi", "pengiriman", "kemasan", "kualitas", "diskon", "refund", "kasir", "cashback", "outlet baru", "ramah lingkungan", "halal"}
+)
+
+type actionTemplate struct {
+	title, description, recommendation, icon string
+}
+
+var actionTemplates = []actionTemplate{
+	{"Address delivery delay complaints", "Mentions about late deliveries of %s spiked on %s over the last week.", "Publish revised delivery estimates and respond to open complaints within 24 hours.", "Package"},
+	{"Respond to pricing backlash", "Customers on %s are comparing the new price of %s unfavourably with competitors.", "Clarify the value of the new price and push a limited bundle offer.", "MessageSquare"},
+	{"Fix app checkout errors", "Users report failed payments when buying %s, mostly through %s.", "Prioritise the checkout bug fix and post a status update on social channels.", "Zap"},
+	{"Contain product quality rumour", "A rumour about the quality of %s is spreading on %s.", "Issue an official statement with lab results and brief customer service.", "AlertTriangle"},
+	{"Amplify positive reviews", "Positive reviews of %s are trending on %s.", "Repost top reviews and invite reviewers to the ambassador programme.", "Target"},
+	{"Improve store queue experience", "Long queues for %s are a recurring theme on %s.", "Add a pre-order option and staff peak hours in the busiest outlets.", "Package"},
+	{"Clarify loyalty point changes", "Members are confused about point changes for %s, mostly on %s.", "Send a clear FAQ to members and pin it on every channel.", "MessageSquare"},
+	{"Follow up on influencer mention", "A large creator mentioned %s on %s with mixed reactions.", "Reach out to the creator and prepare talking points for replies.", "Target"},
+	{"Reduce refund turnaround", "Slow refunds for %s are driving negative threads on %s.", "Automate refunds under a threshold and report progress weekly.", "Zap"},
+	{"Prepare for seasonal demand", "Interest in %s is rising ahead of the holidays, led by %s.", "Secure stock and schedule campaign content two weeks ahead.", "Target"},
+}
+
+func (g *generator) priorityActions(size int) []models.PriorityAction {
+	actions := make([]models.PriorityAction, size)
+	for i, j := range g.cycle(len(actionTemplates), size) {
+		t := actionTemplates[j]
+		trend := pick(g, models.TrendIncreasing, models.TrendStable, models.TrendDecreasing)
+		actions[i] = models.PriorityAction{
+			Priority:       pick(g, models.PriorityCritical, models.PriorityHigh, models.PriorityHigh, models.PriorityMedium, models.PriorityMedium),
+			Title:          t.title,
+			Description:    fmt.Sprintf(t.description, pick(g, products...), pick(g, channels...)),
+			Impact:         pick(g, models.ImpactCritical, models.ImpactHigh, models.ImpactMedium, models.ImpactLow),
+			Effort:         pick(g, models.EffortLow, models.EffortMedium, models.EffortHigh),
+			Recommendation: t.recommendation,
+			Mentions:       50 + g.rand.Intn(5000),
+			Sentiment:      nonZero(g.between(-0.9, 0.8, 2)),
+			Trend:          trend,
+			Icon:           t.icon,
+			Status:         pick(g, models.StatusNotStarted, models.StatusNotStarted, models.StatusInProgress, models.StatusCompleted),
+		}
+		if i >= len(actionTemplates) {
+			actions[i].Title = fmt.Sprintf("%s (%s)", t.title, pick(g, regions...))
+		}
+	}
+	return actions
+}
+
+// nonZero nudges a zero off zero, which required validation rejects.
+func nonZero(value float64) float64 {
+	if value == 0 {
+		return 0.01
+	}
+	return value
+}
+
+type statTemplate struct {
+	label, icon string
+	unit        models.StatUnit
+	min, max    float64
+}
+
+var statTemplates = []statTemplate{
+	{"Total Mentions", "BarChart3", models.StatUnitNumber, 8000, 250000},
+	{"Positive Sentiment", "TrendingUp", models.StatUnitPercent, 35, 80},
+	{"Negative Sentiment", "TrendingDown", models.StatUnitPercent, 5, 35},
+	{"Unique Authors", "Users", models.StatUnitNumber, 1500, 60000},
+	{"Engagement Rate", "Activity", models.StatUnitPercent, 1, 12},
+	{"Share of Voice", "Percent", models.StatUnitPercent, 10, 55},
+	{"Potential Reach", "Eye", models.StatUnitNumber, 100000, 9000000},
+	{"Active Risks", "AlertTriangle", models.StatUnitNumber, 2, 25},
+}
+
+func (g *generator) dashboardStats(size int) []models.DashboardStat {
+	if size > len(statTemplates) {
+		size = len(statTemplates)
+	}
+	stats := make([]models.DashboardStat, size)
+	for i, j := range g.rand.Perm(len(statTemplates))[:size] {
+		t := statTemplates[j]
+		decimals := 0
+		if t.unit == models.StatUnitPercent {
+			decimals = 1
+		}
+		value := g.between(t.min, t.max, decimals)
+		change := g.between(-15, 25, 1)
+
+		stat := models.DashboardStat{
+			Label: t.label,
+			Value: formatValue(value, t.unit),
+			Icon:  t.icon,
+			Unit:  t.unit,
+			Order: i,
+		}
+		stat.Change, stat.Trend = formatChange(change)
+		stats[i] = stat
+	}
+	return stats
+}
+
+func formatValue(value float64, unit models.StatUnit) string {
+	switch {
+	case unit == models.StatUnitPercent:
+		return fmt.Sprintf("%.1f%%", value)
+	case value >= 1e6:
+		return fmt.Sprintf("%.1fM", value/1e6)
+	case value >= 1e3:
+		return fmt.Sprintf("%.1fK", value/1e3)
+	default:
+		return fmt.Sprintf("%.0f", value)
+	}
+}
+
+func formatChange(change float64) (string, models.StatTrend) {
+	if change < 0 {
+		return fmt.Sprintf("%.1f%%", change), models.StatTrendDown
+	}
+	return fmt.Sprintf("+%.1f%%", change), models.StatTrendUp
+}
+
+var trendPeriods = []struct {
+	title, period string
+	days, step    int
+}{
+	{"Sentiment Over Time", "Last 30 days", 30, 1},
+	{"Weekly Sentiment", "Last 12 weeks", 84, 7},
+	{"Campaign Sentiment", "Last 14 days", 14, 1},
+	{"Quarterly Sentiment", "Last 90 days", 90, 3},
+}
+
+func (g *generator) sentimentTrends(size int, end time.Time) []models.SentimentTrend {
+	trends := make([]models.SentimentTrend, size)
+	for i := range trends {
+		p := trendPeriods[i%len(trendPeriods)]
+		title := p.title
+		if round := i / len(trendPeriods); round > 0 {
+			title = fmt.Sprintf("%s (%d)", title, round+1)
+		}
+
+		// A random walk around a baseline, so the series looks like real
+		// daily data and the anomaly detection has something to find
+		positive, negative := g.between(45, 65, 1), g.between(10, 25, 1)
+		var data []models.SentimentDataPoint
+		for day := p.days - p.step; day >= 0; day -= p.step {
+			positive = clamp(positive+g.rand.NormFloat64()*2.5, 20, 85)
+			negative = clamp(negative+g.rand.NormFloat64()*1.5, 3, 100-positive)
+			data = append(data, models.SentimentDataPoint{
+				Date:     end.AddDate(0, 0, -day).Format("2006-01-02"),
+				Positive: math.Round(positive*10) / 10,
+				Negative: math.Round(negative*10) / 10,
+			})
+		}
+		last := data[len(data)-1]
+		trends[i] = models.SentimentTrend{
+			Title:           title,
+			Period:          p.period,
+			PositivePercent: last.Positive,
+			NegativePercent: last.Negative,
+			NeutralPercent:  math.Round((100-last.Positive-last.Negative)*10) / 10,
+			TrendData:       data,
+			Order:           i,
+		}
+	}
+	return trends
+}
+
+func clamp(value, min, max float64) float64 {
+	return math.Max(min, math.Min(max, value))
+}
+
+var (
+	topicNames = []string{"Harga & Promo", "Kualitas Produk", "Pelayanan Outlet", "Aplikasi & Pembayaran", "Pengiriman", "Menu Baru", "Program Loyalty", "Kemasan", "Kebersihan Outlet", "Customer Service", "Kolaborasi Brand", "Isu Lingkungan"}
+	// topicColors are gradient classes of the dashboard bars, from negative
+	// to positive sentiment
+	topicColors = []string{"from-red-500 to-orange-500", "from-orange-500 to-amber-500", "from-amber-500 to-yellow-500", "from-cyan-500 to-blue-500", "from-emerald-500 to-teal-500"}
+)
+
+func (g *generator) discussionTopics(size int) []models.DiscussionTopic {
+	topics := make([]models.DiscussionTopic, size)
+	for i, name := range g.names(topicNames, size) {
+		sentiment := g.between(-0.8, 0.8, 2)
+		topics[i] = models.DiscussionTopic{
+			Name:           name,
+			Volume:         200 + g.rand.Intn(20000),
+			SentimentScore: sentiment,
+			Color:          topicColors[int((sentiment+0.8)/1.6*float64(len(topicColors)-1)+0.5)],
+			Order:          i,
+		}
+	}
+	return topics
+}
+
+var competitorNames = []string{"Kopi Kenangan Kita", "Senja Coffee", "Rasa Nusantara", "Teman Ngopi", "Kedai Kilat", "Janji Pagi", "Titik Temu", "Seduh Bersama"}
+
+func (g *generator) competitiveAnalyses(size int) []models.CompetitiveAnalysis {
+	analyses := make([]models.CompetitiveAnalysis, size)
+	names := g.names(competitorNames, size)
+
+	// Shares of voice add up to 100 across the competitors
+	weights := make([]float64, size)
+	var total float64
+	for i := range weights {
+		weights[i] = 0.5 + g.rand.Float64()
+		total += weights[i]
+	}
+	leader := 0
+	for i := range analyses {
+		share := math.Max(0.1, math.Round(weights[i]/total*1000)/10)
+		analyses[i] = models.CompetitiveAnalysis{
+			Name:         names[i],
+			ShareOfVoice: share,
+			Sentiment:    g.between(40, 85, 1),
+			Engagement:   g.between(0.5, 9, 1),
+			Order:        i,
+		}
+		if share > analyses[leader].ShareOfVoice {
+			leader = i
+		}
+	}
+	for i := range analyses {
+		analysis := &analyses[i]
+		if i == leader {
+			analysis.Position = "#1 in Share of Voice"
+			analysis.GapToLeader = "Leader"
+			continue
+		}
+		rank := 1
+		for _, other := range analyses {
+			if other.ShareOfVoice > analysis.ShareOfVoice {
+				rank++
+			}
+		}
+		analysis.Position = fmt.Sprintf("#%d in Share of Voice", rank)
+		analysis.GapToLeader = fmt.Sprintf("Behind by %.1f%%", analyses[leader].ShareOfVoice-analysis.ShareOfVoice)
+	}
+	return analyses
+}
+
+var clusterThemes = []string{"Keluhan antrean saat jam sibuk", "Pujian untuk menu musiman", "Masalah login aplikasi", "Perbandingan harga dengan kompetitor", "Pengalaman pesan antar", "Kemasan ramah lingkungan", "Promo gajian", "Keramahan barista", "Poin loyalty hangus", "Outlet baru di luar Jawa"}
+
+func (g *generator) conversationClusters(size int) []models.ConversationCluster {
+	clusters := make([]models.ConversationCluster, size)
+	for i, theme := range g.names(clusterThemes, size) {
+		clusters[i] = models.ConversationCluster{
+			Theme:     theme,
+			Size:      100 + g.rand.Intn(8000),
+			Sentiment: g.between(-0.8, 0.8, 2),
+			Trend:     pick(g, "up", "down", "stable"),
+			Keywords:  g.sample(keywords, 3+g.rand.Intn(3)),
+			Order:     i,
+		}
+	}
+	return clusters
+}
+
+type riskTemplate struct {
+	title, description, impact string
+	indicators                 []string
+	mitigation                 []string
+}
+
+var riskTemplates = []riskTemplate{
+	{"Viral complaint thread", "A complaint about %s is gaining traction on %s and may reach mainstream media.", "Brand trust drops among first-time customers in %s.", []string{"Negative mentions", "Share rate"}, []string{"Respond publicly within 2 hours", "Offer a direct resolution to the author", "Monitor related hashtags hourly"}},
+	{"Price perception decline", "Discussions on %s frame %s as overpriced compared with competitors.", "Lower conversion in price-sensitive regions such as %s.", []string{"Price-related mentions", "Competitor comparisons"}, []string{"Highlight value bundles", "Brief sales teams on talking points"}},
+	{"Service outage backlash", "Outages affecting %s generate bursts of frustration on %s.", "Churn risk for app users in %s.", []string{"Outage mentions", "App store rating"}, []string{"Publish a status page", "Compensate affected users with vouchers"}},
+	{"Food safety rumour", "An unverified claim about %s circulates on %s.", "Possible regulatory attention and outlet traffic decline in %s.", []string{"Rumour mentions", "News pickups"}, []string{"Share certification documents", "Coordinate with the regulator", "Prepare a spokesperson"}},
+	{"Employee conduct video", "A video involving staff at an outlet selling %s is shared on %s.", "Reputation damage concentrated in %s.", []string{"Video views", "Negative replies"}, []string{"Investigate and respond transparently", "Refresh service training"}},
+	{"Competitor campaign pressure", "A competitor campaign targeting %s dominates %s.", "Loss of share of voice in %s.", []string{"Competitor share of voice", "Campaign hashtag volume"}, []string{"Accelerate the counter campaign", "Engage loyal customers"}},
+}
+
+func (g *generator) risks(size int) []models.Risk {
+	risks := make([]models.Risk, size)
+	for i, j := range g.cycle(len(riskTemplates), size) {
+		t := riskTemplates[j]
+		indicators := make([]models.RiskIndicator, len(t.indicators))
+		for j, label := range t.indicators {
+			indicators[j] = models.RiskIndicator{Label: label, Value: float64(20 + g.rand.Intn(2000)), Change: g.between(-20, 60, 0)}
+		}
+		risks[i] = models.Risk{
+			Title:              t.title,
+			Description:        fmt.Sprintf(t.description, pick(g, products...), pick(g, channels...)),
+			Severity:           pick(g, models.RiskSeverityCritical, models.RiskSeverityHigh, models.RiskSeverityMedium, models.RiskSeverityMedium, models.RiskSeverityLow),
+			Probability:        5 + g.rand.Intn(91),
+			ImpactAssessment:   fmt.Sprintf(t.impact, pick(g, regions...)),
+			Trend:              pick(g, models.RiskTrendIncreasing, models.RiskTrendStable, models.RiskTrendDecreasing),
+			Indicators:         indicators,
+			MitigationStrategy: t.mitigation,
+			Order:              i,
+		}
+		if i >= len(riskTemplates) {
+			risks[i].Title = fmt.Sprintf("%s (%s)", t.title, pick(g, regions...))
+		}
+	}
+	return risks
+}
+
+type opportunityTemplate struct {
+	title, description, category string
+	actions                      []string
+}
+
+var opportunityTemplates = []opportunityTemplate{
+	{"Ride the seasonal menu buzz", "Organic excitement about %s is growing on %s.", "Product", []string{"Extend the menu period", "Launch a user-generated content challenge"}},
+	{"Partner with micro-influencers", "Micro-influencers on %s already recommend %s without sponsorship.", "Marketing", []string{"Invite them to a seeding programme", "Track referral codes per creator"}},
+	{"Expand delivery coverage", "Requests for %s delivery keep appearing on %s from uncovered areas.", "Operations", []string{"Pilot delivery in two new areas", "Partner with a local courier"}},
+	{"Promote eco-friendly packaging", "Positive talk about %s packaging is rising on %s.", "Sustainability", []string{"Publish the packaging story", "Offer a reusable cup discount"}},
+	{"Win over competitor detractors", "Competitor customers on %s are asking for alternatives to %s.", "Acquisition", []string{"Run a switch-and-save promo", "Answer comparison questions quickly"}},
+	{"Improve app ratings", "Recent feedback on %s praises the new %s flow.", "Digital", []string{"Prompt satisfied users to rate the app", "Showcase the new flow in onboarding"}},
+}
+
+func (g *generator) opportunities(size int) []models.Opportunity {
+	opportunities := make([]models.Opportunity, size)
+	for i, j := range g.cycle(len(opportunityTemplates), size) {
+		t := opportunityTemplates[j]
+		opportunities[i] = models.Opportunity{
+			Title:           t.title,
+			Description:     fmt.Sprintf(t.description, pick(g, products...), pick(g, channels...)),
+			Potential:       pick(g, models.OpportunityPotentialHigh, models.OpportunityPotentialMedium, models.OpportunityPotentialLow),
+			ConfidenceScore: 40 + g.rand.Intn(56),
+			Timeframe:       pick(g, models.OpportunityTimeframeShort, models.OpportunityTimeframeMedium, models.OpportunityTimeframeLong),
+			Category:        t.category,
+			Trend:           pick(g, models.OpportunityTrendIncreasing, models.OpportunityTrendStable, models.OpportunityTrendDecreasing),
+			KeyMetrics: []models.KeyMetric{
+				{Label: "Mentions", Value: fmt.Sprintf("%d", 100+g.rand.Intn(9000))},
+				{Label: "Positive sentiment", Value: fmt.Sprintf("%d%%", 50+g.rand.Intn(45))},
+			},
+			RecommendedActions: t.actions,
+			Order:              i,
+		}
+		if i >= len(opportunityTemplates) {
+			opportunities[i].Title = fmt.Sprintf("%s (%s)", t.title, pick(g, regions...))
+		}
+	}
+	return opportunities
+}
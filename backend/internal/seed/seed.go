@@ -0,0 +1,139 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/service"
+)
+
+// Collections are the collections Reset empties: the dashboard content and
+// what hangs off it. Snapshots, report schedules and migrations are kept.
+var Collections = []string{
+	"priority_actions",
+	"dashboard_stats",
+	"dashboard_stat_points",
+	"sentiment_trends",
+	"discussion_topics",
+	"competitive_analyses",
+	"conversation_clusters",
+	"risks",
+	"opportunities",
+	"relations",
+	"evidence",
+}
+
+// Services are the services fixtures are created through, so seeded items
+// get the same validation and defaults as items created with the API.
+type Services struct {
+	PriorityActions      *service.PriorityActionService
+	DashboardStats       *service.DashboardStatService
+	SentimentTrends      *service.SentimentTrendService
+	DiscussionTopics     *service.DiscussionTopicService
+	CompetitiveAnalyses  *service.CompetitiveAnalysisService
+	ConversationClusters *service.ConversationClusterService
+	Risks                *service.RiskService
+	Opportunities        *service.OpportunityService
+}
+
+// Count is the number of documents created in or deleted from a collection.
+type Count struct {
+	Collection string
+	Documents  int
+}
+
+// collection binds the fixtures of one collection to its service.
+type collection[T any] struct {
+	name     string
+	items    []T
+	validate func(item *T) error
+	create   func(ctx context.Context, item *T) error
+}
+
+func (c collection[T]) check() []error {
+	var errs []error
+	for i := range c.items {
+		if err := c.validate(&c.items[i]); err != nil {
+			errs = append(errs, fmt.Errorf("%s[%d]: %w", c.name, i, err))
+		}
+	}
+	return errs
+}
+
+func (c collection[T]) load(ctx context.Context) (Count, error) {
+	count := Count{Collection: c.name}
+	for i := range c.items {
+		if err := c.create(ctx, &c.items[i]); err != nil {
+			return count, fmt.Errorf("%s[%d]: %w", c.name, i, err)
+		}
+		count.Documents++
+	}
+	return count, nil
+}
+
+type loader interface {
+	check() []error
+	load(ctx context.Context) (Count, error)
+}
+
+func loaders(svc Services, fixtures *Fixtures) []loader {
+	return []loader{
+		collection[models.PriorityAction]{"priority_actions", fixtures.PriorityActions, svc.PriorityActions.Validate, svc.PriorityActions.Create},
+		collection[models.DashboardStat]{"dashboard_stats", fixtures.DashboardStats, svc.DashboardStats.Validate, svc.DashboardStats.Create},
+		collection[models.SentimentTrend]{"sentiment_trends", fixtures.SentimentTrends, svc.SentimentTrends.Validate, svc.SentimentTrends.Create},
+		collection[models.DiscussionTopic]{"discussion_topics", fixtures.DiscussionTopics, svc.DiscussionTopics.Validate, svc.DiscussionTopics.Create},
+		collection[models.CompetitiveAnalysis]{"competitive_analyses", fixtures.CompetitiveAnalyses, svc.CompetitiveAnalyses.Validate, svc.CompetitiveAnalyses.Create},
+		collection[models.ConversationCluster]{"conversation_clusters", fixtures.ConversationClusters, svc.ConversationClusters.Validate, svc.ConversationClusters.Create},
+		collection[models.Risk]{"risks", fixtures.Risks, svc.Risks.Validate, svc.Risks.Create},
+		collection[models.Opportunity]{"opportunities", fixtures.Opportunities, svc.Opportunities.Validate, svc.Opportunities.Create},
+	}
+}
+
+// Validate checks every fixture item with the rules of its service and lists
+// each invalid one.
+func Validate(svc Services, fixtures *Fixtures) error {
+	var errs []error
+	for _, l := range loaders(svc, fixtures) {
+		errs = append(errs, l.check()...)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid fixtures:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Load creates every fixture item. Nothing is written when any item is
+// invalid.
+func Load(ctx context.Context, svc Services, fixtures *Fixtures) ([]Count, error) {
+	if err := Validate(svc, fixtures); err != nil {
+		return nil, err
+	}
+
+	var counts []Count
+	for _, l := range loaders(svc, fixtures) {
+		count, err := l.load(ctx)
+		counts = append(counts, count)
+		if err != nil {
+			return counts, err
+		}
+	}
+	return counts, nil
+}
+
+// Reset deletes every document in Collections. Documents are deleted rather
+// than the collections dropped, so indexes and validators stay in place.
+func Reset(ctx context.Context, db *mongo.Database) ([]Count, error) {
+	counts := make([]Count, 0, len(Collections))
+	for _, name := range Collections {
+		result, err := db.Collection(name).DeleteMany(ctx, bson.M{})
+		if err != nil {
+			return counts, fmt.Errorf("%s: %w", name, err)
+		}
+		counts = append(counts, Count{Collection: name, Documents: int(result.DeletedCount)})
+	}
+	return counts, nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"naradai-backend/internal/anomaly"
+	"naradai-backend/internal/models"
+)
+
+// mentionSeriesLimit is how many recent points of a mention volume series are
+// scanned for anomalies.
+const mentionSeriesLimit = 500
+
+// AnomalyService scans the sentiment trend series and the time series of
+// mention volume stats for anomalous points.
+type AnomalyService struct {
+	trends *SentimentTrendService
+	stats  *DashboardStatService
+}
+
+func NewAnomalyService(trends *SentimentTrendService, stats *DashboardStatService) *AnomalyService {
+	return &AnomalyService{trends: trends, stats: stats}
+}
+
+// List returns the anomalies of the active series of source, or of every
+// source when it is empty, in series order.
+func (s *AnomalyService) List(ctx context.Context, source string, opts anomaly.Options) ([]models.Anomaly, error) {
+	ctx, span := tracer.Start(ctx, "AnomalyService.List")
+	defer span.End()
+
+	var anomalies []models.Anomaly
+
+	if source == "" || source == "sentiment_trends" {
+		trends, _, err := s.trends.GetAll(ctx, bson.M{"is_active": true}, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		for i := range trends {
+			trend := &trends[i]
+			for _, series := range sentimentSeries(trend) {
+				for _, result := range anomaly.Detect(series.values, opts) {
+					anomalies = append(anomalies, models.Anomaly{
+						PointAnomaly: pointAnomaly(series.name, result),
+						Source:       "sentiment_trends",
+						SourceID:     trend.ID,
+						Title:        trend.Title,
+						Date:         trend.TrendData[result.Index].Date,
+						Value:        result.Value,
+					})
+				}
+			}
+		}
+	}
+
+	if source == "" || source == "dashboard_stats" {
+		filter := bson.M{"is_active": true, "binding.metric": models.StatMetricMentionCount}
+		stats, _, err := s.stats.GetAll(ctx, filter, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		for i := range stats {
+			stat := &stats[i]
+			_, points, err := s.stats.Series(ctx, stat.ID.Hex(), time.Time{}, time.Time{}, mentionSeriesLimit)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]float64, len(points))
+			for j, point := range points {
+				values[j] = point.Value
+			}
+			for _, result := range anomaly.Detect(values, opts) {
+				anomalies = append(anomalies, models.Anomaly{
+					PointAnomaly: pointAnomaly("mentions", result),
+					Source:       "dashboard_stats",
+					SourceID:     stat.ID,
+					Title:        stat.Label,
+					Date:         points[result.Index].At.Format(time.RFC3339),
+					Value:        result.Value,
+				})
+			}
+		}
+	}
+
+	return anomalies, nil
+}
+
+// AnnotatedPoint is a sentiment data point with the anomalies found on it
+type AnnotatedPoint struct {
+	models.SentimentDataPoint
+	Anomalies []models.PointAnomaly `json:"anomalies,omitempty"`
+}
+
+// AnnotateTrend returns the trend's data points with anomalies of the
+// positive and negative series attached, using the default detector options.
+func AnnotateTrend(trend *models.SentimentTrend) []AnnotatedPoint {
+	points := make([]AnnotatedPoint, len(trend.TrendData))
+	for i, point := range trend.TrendData {
+		points[i].SentimentDataPoint = point
+	}
+	for _, series := range sentimentSeries(trend) {
+		for _, result := range anomaly.Detect(series.values, anomaly.Options{}) {
+			points[result.Index].Anomalies = append(points[result.Index].Anomalies, pointAnomaly(series.name, result))
+		}
+	}
+	return points
+}
+
+type namedSeries struct {
+	name   string
+	values []float64
+}
+
+func sentimentSeries(trend *models.SentimentTrend) []namedSeries {
+	positive := make([]float64, len(trend.TrendData))
+	negative := make([]float64, len(trend.TrendData))
+	for i, point := range trend.TrendData {
+		positive[i] = point.Positive
+		negative[i] = point.Negative
+	}
+	return []namedSeries{{"positive", positive}, {"negative", negative}}
+}
+
+func pointAnomaly(series string, result anomaly.Result) models.PointAnomaly {
+	return models.PointAnomaly{
+		Series:    series,
+		Expected:  math.Round(result.Expected*100) / 100,
+		ZScore:    math.Round(result.ZScore*100) / 100,
+		Direction: result.Direction(),
+	}
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"naradai-backend/internal/models"
+)
+
+// DashboardService reads all dashboard resources together, for exports and
+// reports that cover the whole dashboard.
+type DashboardService struct {
+	stats           *DashboardStatService
+	priorityActions *PriorityActionService
+	risks           *RiskService
+	opportunities   *OpportunityService
+	sentimentTrends *SentimentTrendService
+	topics          *DiscussionTopicService
+	competitors     *CompetitiveAnalysisService
+	clusters        *ConversationClusterService
+}
+
+func NewDashboardService(
+	stats *DashboardStatService,
+	priorityActions *PriorityActionService,
+	risks *RiskService,
+	opportunities *OpportunityService,
+	sentimentTrends *SentimentTrendService,
+	topics *DiscussionTopicService,
+	competitors *CompetitiveAnalysisService,
+	clusters *ConversationClusterService,
+) *DashboardService {
+	return &DashboardService{
+		stats:           stats,
+		priorityActions: priorityActions,
+		risks:           risks,
+		opportunities:   opportunities,
+		sentimentTrends: sentimentTrends,
+		topics:          topics,
+		competitors:     competitors,
+		clusters:        clusters,
+	}
+}
+
+// Load returns the active items of every resource. Priority actions have no
+// active flag, so all of them are included.
+func (s *DashboardService) Load(ctx context.Context) (*models.Dashboard, error) {
+	ctx, span := tracer.Start(ctx, "DashboardService.Load")
+	defer span.End()
+
+	active := bson.M{"is_active": true}
+	dashboard := &models.Dashboard{GeneratedAt: time.Now()}
+
+	var err error
+	if dashboard.Stats, _, err = s.stats.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.PriorityActions, _, err = s.priorityActions.GetAll(ctx, bson.M{}, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.Risks, _, err = s.risks.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.Opportunities, _, err = s.opportunities.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.SentimentTrends, _, err = s.sentimentTrends.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.DiscussionTopics, _, err = s.topics.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.CompetitiveAnalyses, _, err = s.competitors.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	if dashboard.ConversationClusters, _, err = s.clusters.GetAll(ctx, active, 0, 0); err != nil {
+		return nil, err
+	}
+	return dashboard, nil
+}
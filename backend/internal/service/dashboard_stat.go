@@ -2,75 +2,173 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"time"
 
-	"github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"naradai-backend/internal/models"
 	"naradai-backend/internal/repository"
+	"naradai-backend/internal/resource"
 )
 
+// ErrInvalidPoint is returned for a time series point that cannot be stored.
+var ErrInvalidPoint = errors.New("invalid point")
+
+// DashboardStatService is the service of dashboard stats. Besides the
+// shared operations it keeps the time series of each stat: points recorded
+// directly or sampled from a binding's metric.
 type DashboardStatService struct {
-	repo      *repository.DashboardStatRepository
-	validator *validator.Validate
+	*resource.Service[models.DashboardStat, *models.DashboardStat]
+	repo    repository.OrderedStore[models.DashboardStat]
+	points  repository.StatPointStore
+	metrics repository.StatMetricStore
 }
 
-func NewDashboardStatService(repo *repository.DashboardStatRepository) *DashboardStatService {
-	return &DashboardStatService{
-		repo:      repo,
-		validator: validator.New(),
+func NewDashboardStatService(repo repository.OrderedStore[models.DashboardStat], points repository.StatPointStore, metrics repository.StatMetricStore) *DashboardStatService {
+	s := &DashboardStatService{
+		repo:    repo,
+		points:  points,
+		metrics: metrics,
 	}
+	s.Service = resource.NewService(resource.DashboardStats, repo, resource.Hooks[models.DashboardStat]{
+		Validate: validateBinding,
+		Prepare:  prepareStat,
+		Loaded:   s.resolveLive,
+		Saved:    s.saved,
+		Deleted: func(ctx context.Context, stat *models.DashboardStat) error {
+			return s.points.DeleteByStat(ctx, stat.ID)
+		},
+	})
+	return s
 }
 
-func (s *DashboardStatService) Validate(stat *models.DashboardStat) error {
-	return s.validator.Struct(stat)
+// validateBinding checks the window of a stat's binding, which the validate
+// tags cannot.
+func validateBinding(stat *models.DashboardStat) error {
+	if stat.Binding != nil {
+		if _, err := parseWindow(stat.Binding.Window); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (s *DashboardStatService) Create(ctx context.Context, stat *models.DashboardStat) error {
-	if err := s.Validate(stat); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
+// prepareStat drops a numeric value sent by the client, which only the time
+// series sets, and gives a bound stat without a unit the unit of its metric.
+func prepareStat(stat *models.DashboardStat) {
+	stat.NumericValue = nil
+	if stat.Binding != nil && stat.Unit == "" {
+		stat.Unit = metricUnit(stat.Binding.Metric)
 	}
-	return s.repo.Create(ctx, stat)
 }
 
-func (s *DashboardStatService) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.DashboardStat, int64, error) {
-	return s.repo.GetAll(ctx, filter, limit, offset)
-}
+// saved brings the computed fields of a stat up to date after it is written.
+// A new stat with a binding gets a first sample, so it does not show the
+// placeholder value until the next scheduled refresh, and is reloaded into
+// stat. A replaced or patched stat is sampled again when bound; otherwise a
+// unit change reformats the computed fields of a stat with a series.
+func (s *DashboardStatService) saved(ctx context.Context, stat *models.DashboardStat, created bool) error {
+	if !created {
+		if stat.Binding != nil {
+			return s.sample(ctx, stat, time.Now().Truncate(time.Minute))
+		}
+		return s.refresh(ctx, stat.ID.Hex())
+	}
 
-func (s *DashboardStatService) GetByID(ctx context.Context, id string) (*models.DashboardStat, error) {
-	return s.repo.GetByID(ctx, id)
+	if stat.Binding == nil {
+		return nil
+	}
+	if err := s.sample(ctx, stat, time.Now().Truncate(time.Minute)); err != nil {
+		return err
+	}
+	stored, err := s.GetByID(ctx, stat.ID.Hex())
+	if err != nil {
+		return err
+	}
+	*stat = *stored
+	return nil
 }
 
-func (s *DashboardStatService) Update(ctx context.Context, id string, stat *models.DashboardStat) error {
-	if err := s.Validate(stat); err != nil {
-		return fmt.Errorf("validation failed: %w", err)
-	}
+// RecordPoints adds values to the stat's time series and recomputes its
+// Value, Change and Trend from the latest two points. Points without a
+// timestamp are recorded at the current time.
+func (s *DashboardStatService) RecordPoints(ctx context.Context, id string, points []models.StatPoint) (*models.DashboardStat, error) {
+	ctx, span := tracer.Start(ctx, "DashboardStatService.RecordPoints")
+	defer span.End()
 
-	// Check if exists
-	_, err := s.repo.GetByID(ctx, id)
+	stat, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return fmt.Errorf("dashboard stat not found")
+			return nil, fmt.Errorf("dashboard stat %w", resource.ErrNotFound)
 		}
-		return err
+		return nil, err
 	}
 
-	return s.repo.Update(ctx, id, stat)
+	now := time.Now().Truncate(time.Second)
+	for i := range points {
+		if math.IsNaN(points[i].Value) || math.IsInf(points[i].Value, 0) {
+			return nil, fmt.Errorf("%w: point %d has no finite value", ErrInvalidPoint, i)
+		}
+		points[i].StatID = stat.ID
+		if points[i].At.IsZero() {
+			points[i].At = now
+		}
+	}
+	if err := s.points.Record(ctx, points); err != nil {
+		return nil, err
+	}
+	if err := s.refresh(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
 }
 
-func (s *DashboardStatService) Delete(ctx context.Context, id string) error {
-	// Check if exists
-	_, err := s.repo.GetByID(ctx, id)
+// Series returns the stat with up to limit of its most recent points between
+// from and to, oldest first.
+func (s *DashboardStatService) Series(ctx context.Context, id string, from, to time.Time, limit int64) (*models.DashboardStat, []models.StatPoint, error) {
+	ctx, span := tracer.Start(ctx, "DashboardStatService.Series")
+	defer span.End()
+
+	stat, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return fmt.Errorf("dashboard stat not found")
+			return nil, nil, fmt.Errorf("dashboard stat %w", resource.ErrNotFound)
 		}
-		return err
+		return nil, nil, err
 	}
-
-	return s.repo.Delete(ctx, id)
+	points, err := s.points.Range(ctx, stat.ID, from, to, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stat, points, nil
 }
 
+// refresh recomputes the display fields of a stat that has a time series.
+// Stats without recorded points keep their manual values.
+func (s *DashboardStatService) refresh(ctx context.Context, id string) error {
+	stat, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	latest, err := s.points.Latest(ctx, stat.ID, 2)
+	if err != nil || len(latest) == 0 {
+		return err
+	}
 
-
+	current := latest[0].Value
+	previous := current
+	if len(latest) > 1 {
+		previous = latest[1].Value
+	}
+	change, trend := FormatStatChange(previous, current, stat.Unit)
+	// The computed fields change without regard to the version the caller read
+	return s.repo.Patch(ctx, id, bson.M{
+		"value":         FormatStatValue(current, stat.Unit, stat.Currency),
+		"change":        change,
+		"trend":         trend,
+		"numeric_value": current,
+	}, repository.AnyVersion)
+}
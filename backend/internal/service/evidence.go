@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// MaxEvidence is the most evidence mentions an item can carry.
+const MaxEvidence = 50
+
+// ErrEvidenceLimit is returned when adding evidence to an item that already
+// has MaxEvidence mentions.
+var ErrEvidenceLimit = fmt.Errorf("an item can have at most %d evidence mentions", MaxEvidence)
+
+type EvidenceService struct {
+	repo      repository.EvidenceStore
+	items     *Items
+	validator *validator.Validate
+}
+
+func NewEvidenceService(repo repository.EvidenceStore, items *Items) *EvidenceService {
+	return &EvidenceService{
+		repo:      repo,
+		items:     items,
+		validator: validator.New(),
+	}
+}
+
+func (s *EvidenceService) Validate(evidence *models.Evidence) error {
+	return s.validator.Struct(evidence)
+}
+
+// item loads the item evidence is attached to, returning ErrItemNotFound
+// when it does not exist.
+func (s *EvidenceService) item(ctx context.Context, ref models.ResourceRef) (map[string]interface{}, error) {
+	item, err := s.items.Get(ctx, ref)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("%w: %s %s", ErrItemNotFound, ref.Type, ref.ID.Hex())
+	}
+	return item, err
+}
+
+// List returns the evidence of the item in the order it was added.
+func (s *EvidenceService) List(ctx context.Context, ref models.ResourceRef) ([]models.Evidence, error) {
+	ctx, span := tracer.Start(ctx, "EvidenceService.List")
+	defer span.End()
+
+	if _, err := s.item(ctx, ref); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByItem(ctx, ref)
+}
+
+// Add attaches a hand-picked mention to the item.
+func (s *EvidenceService) Add(ctx context.Context, ref models.ResourceRef, evidence *models.Evidence) error {
+	ctx, span := tracer.Start(ctx, "EvidenceService.Add")
+	defer span.End()
+
+	evidence.Item = ref
+	evidence.AutoSelected = false
+	if err := validated(ctx, s.Validate, evidence); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if _, err := s.item(ctx, ref); err != nil {
+		return err
+	}
+	count, err := s.repo.CountByItem(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if count >= MaxEvidence {
+		return ErrEvidenceLimit
+	}
+
+	return s.repo.Create(ctx, evidence)
+}
+
+func (s *EvidenceService) Remove(ctx context.Context, ref models.ResourceRef, id string) error {
+	ctx, span := tracer.Start(ctx, "EvidenceService.Remove")
+	defer span.End()
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return fmt.Errorf("evidence not found")
+	}
+	if err := s.repo.Delete(ctx, ref, id); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("evidence not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Select picks up to limit of the most representative candidates (see
+// selectRepresentative) and stores them as the item's automatically selected
+// evidence, replacing the previous selection. Evidence added by hand is kept
+// and counts towards MaxEvidence. Ingestion calls this with the mentions an
+// item was generated from.
+func (s *EvidenceService) Select(ctx context.Context, ref models.ResourceRef, candidates []models.Evidence, limit int) ([]models.Evidence, error) {
+	ctx, span := tracer.Start(ctx, "EvidenceService.Select")
+	defer span.End()
+
+	for i := range candidates {
+		candidates[i].Item = ref
+		if err := s.Validate(&candidates[i]); err != nil {
+			return nil, fmt.Errorf("validation failed: candidate %d: %w", i, err)
+		}
+	}
+	item, err := s.item(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteAutoSelected(ctx, ref); err != nil {
+		return nil, err
+	}
+	manual, err := s.repo.CountByItem(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if room := MaxEvidence - int(manual); limit > room {
+		limit = room
+	}
+
+	picked := selectRepresentative(candidates, itemProfile(item), limit)
+	selected := make([]*models.Evidence, len(picked))
+	for i := range picked {
+		picked[i].Item = ref
+		picked[i].AutoSelected = true
+		selected[i] = &picked[i]
+	}
+	if err := s.repo.Create(ctx, selected...); err != nil {
+		return nil, err
+	}
+	return picked, nil
+}
+
+// DeleteForItem removes the evidence of a deleted item.
+func (s *EvidenceService) DeleteForItem(ctx context.Context, ref models.ResourceRef) error {
+	ctx, span := tracer.Start(ctx, "EvidenceService.DeleteForItem")
+	defer span.End()
+
+	return s.repo.DeleteByItem(ctx, ref)
+}
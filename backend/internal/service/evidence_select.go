@@ -0,0 +1,166 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"naradai-backend/internal/models"
+)
+
+// evidenceProfile is what a representative mention of an item should look
+// like: close to the item's sentiment and using its keywords.
+type evidenceProfile struct {
+	sentiment *float64
+	keywords  []string
+}
+
+// itemProfile reads the sentiment and keywords of an item from its response
+// map. Clusters carry both; topics and actions carry a sentiment, and a
+// topic's name doubles as its keyword. Risks and opportunities have neither.
+func itemProfile(item map[string]interface{}) evidenceProfile {
+	var profile evidenceProfile
+	for _, key := range []string{"sentiment", "sentiment_score"} {
+		if value, ok := item[key].(float64); ok && value >= -1 && value <= 1 {
+			profile.sentiment = &value
+			break
+		}
+	}
+	if keywords, ok := item["keywords"].([]string); ok {
+		profile.keywords = keywords
+	}
+	if name, ok := item["name"].(string); ok && name != "" {
+		profile.keywords = append(profile.keywords, name)
+	}
+	return profile
+}
+
+// selectRepresentative picks up to limit candidates, best first. Each
+// candidate is scored on:
+//
+//   - sentiment: closeness to the item's sentiment, or to the median of the
+//     candidates when the item has none (half the score)
+//   - keywords: the share of the item's keywords it mentions
+//   - length: posts of 40 to 280 characters read best as quotes
+//
+// Picks are greedy; a candidate from a source already picked scores lower,
+// and one that repeats more than 60% of the words of an earlier pick is
+// skipped, so the selection does not show the same post twice.
+func selectRepresentative(candidates []models.Evidence, profile evidenceProfile, limit int) []models.Evidence {
+	if limit <= 0 || len(candidates) == 0 {
+		return nil
+	}
+
+	target := 0.0
+	if profile.sentiment != nil {
+		target = *profile.sentiment
+	} else {
+		sentiments := make([]float64, len(candidates))
+		for i, c := range candidates {
+			sentiments[i] = c.Sentiment
+		}
+		sort.Float64s(sentiments)
+		target = sentiments[len(sentiments)/2]
+	}
+
+	keywords := make([]string, 0, len(profile.keywords))
+	for _, keyword := range profile.keywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	type scored struct {
+		index int
+		score float64
+		words map[string]bool
+	}
+	pool := make([]scored, len(candidates))
+	for i, c := range candidates {
+		text := strings.ToLower(c.Text)
+		score := 0.5 * (1 - math.Abs(c.Sentiment-target)/2)
+		if len(keywords) > 0 {
+			matched := 0
+			for _, keyword := range keywords {
+				if strings.Contains(text, keyword) {
+					matched++
+				}
+			}
+			score += 0.35 * float64(matched) / float64(len(keywords))
+		} else {
+			score += 0.35
+		}
+		score += 0.15 * lengthScore(len([]rune(c.Text)))
+		pool[i] = scored{index: i, score: score, words: wordSet(text)}
+	}
+
+	var picked []scored
+	used := make([]bool, len(pool))
+	sources := map[string]int{}
+	for len(picked) < limit {
+		best := -1
+		bestScore := 0.0
+	candidates:
+		for i, candidate := range pool {
+			if used[i] {
+				continue
+			}
+			for _, p := range picked {
+				if jaccard(candidate.words, p.words) > 0.6 {
+					continue candidates
+				}
+			}
+			score := candidate.score * math.Pow(0.8, float64(sources[strings.ToLower(candidates[i].Source)]))
+			if best < 0 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			break
+		}
+		used[best] = true
+		picked = append(picked, pool[best])
+		sources[strings.ToLower(candidates[best].Source)]++
+	}
+
+	selected := make([]models.Evidence, len(picked))
+	for i, p := range picked {
+		selected[i] = candidates[p.index]
+	}
+	return selected
+}
+
+func lengthScore(length int) float64 {
+	switch {
+	case length < 40:
+		return float64(length) / 40
+	case length <= 280:
+		return 1
+	default:
+		return math.Max(0, 1-float64(length-280)/720)
+	}
+}
+
+func wordSet(text string) map[string]bool {
+	words := map[string]bool{}
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/forecast"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/resource"
+)
+
+// MaxForecastDays bounds the horizon of a forecast.
+const MaxForecastDays = 90
+
+// ErrTooShort is returned when a series has too few points to forecast.
+var ErrTooShort = forecast.ErrTooShort
+
+// ForecastPoint is one projected value with its confidence interval
+type ForecastPoint struct {
+	Value float64 `json:"value"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// SentimentForecastPoint projects both series of a sentiment trend on a date
+type SentimentForecastPoint struct {
+	Date     string        `json:"date"`
+	Positive ForecastPoint `json:"positive"`
+	Negative ForecastPoint `json:"negative"`
+}
+
+// SentimentForecast is a sentiment trend's history and its projection
+type SentimentForecast struct {
+	Trend    *models.SentimentTrend
+	Method   string
+	Level    float64
+	Forecast []SentimentForecastPoint
+}
+
+// Forecast projects the positive and negative series of the trend days ahead.
+// The trend's points need not be daily: the spacing of the last two dates is
+// kept, so a trend with a point every four days gets one every four days.
+// Percentages are clamped to 0-100.
+func (s *SentimentTrendService) Forecast(ctx context.Context, id string, days int, opts forecast.Options) (*SentimentForecast, error) {
+	ctx, span := tracer.Start(ctx, "SentimentTrendService.Forecast")
+	defer span.End()
+
+	trend, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("sentiment trend %w", resource.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	dates := make([]string, len(trend.TrendData))
+	for i, point := range trend.TrendData {
+		dates[i] = point.Date
+	}
+	future := futureDates(dates, days, time.Now())
+
+	positive, negative := make([]float64, len(trend.TrendData)), make([]float64, len(trend.TrendData))
+	for i, point := range trend.TrendData {
+		positive[i] = point.Positive
+		negative[i] = point.Negative
+	}
+	positiveResult, err := forecast.Forecast(positive, len(future), opts)
+	if err != nil {
+		return nil, err
+	}
+	negativeResult, err := forecast.Forecast(negative, len(future), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SentimentForecast{
+		Trend:    trend,
+		Method:   positiveResult.Method,
+		Level:    forecastLevel(opts),
+		Forecast: make([]SentimentForecastPoint, len(future)),
+	}
+	for i, date := range future {
+		result.Forecast[i] = SentimentForecastPoint{
+			Date:     date,
+			Positive: forecastPoint(positiveResult.Points[i], 0, 100),
+			Negative: forecastPoint(negativeResult.Points[i], 0, 100),
+		}
+	}
+	return result, nil
+}
+
+// StatForecastPoint is the projected value of a stat on a date
+type StatForecastPoint struct {
+	Date string `json:"date"`
+	ForecastPoint
+}
+
+// StatForecast is a stat's daily history and its projection
+type StatForecast struct {
+	Stat     *models.DashboardStat
+	Method   string
+	Level    float64
+	History  []StatForecastPoint
+	Forecast []StatForecastPoint
+}
+
+// Forecast projects the stat's time series, such as the mention volume of a
+// bound stat, days ahead. The series is reduced to the last value of each UTC
+// day first, and projected values are not allowed below zero.
+func (s *DashboardStatService) Forecast(ctx context.Context, id string, days int, opts forecast.Options) (*StatForecast, error) {
+	ctx, span := tracer.Start(ctx, "DashboardStatService.Forecast")
+	defer span.End()
+
+	stat, points, err := s.Series(ctx, id, time.Now().AddDate(0, 0, -365), time.Time{}, 100000)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []StatForecastPoint
+	var values []float64
+	for _, point := range points {
+		date := point.At.UTC().Format("2006-01-02")
+		if n := len(history); n > 0 && history[n-1].Date == date {
+			history[n-1].Value = point.Value
+			values[n-1] = point.Value
+			continue
+		}
+		history = append(history, StatForecastPoint{Date: date, ForecastPoint: ForecastPoint{Value: point.Value}})
+		values = append(values, point.Value)
+	}
+
+	result, err := forecast.Forecast(values, days, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := make([]StatForecastPoint, days)
+	last := time.Now().UTC()
+	if len(history) > 0 {
+		last, _ = time.Parse("2006-01-02", history[len(history)-1].Date)
+	}
+	for i, point := range result.Points {
+		projection[i] = StatForecastPoint{
+			Date:          last.AddDate(0, 0, i+1).Format("2006-01-02"),
+			ForecastPoint: forecastPoint(point, 0, math.Inf(1)),
+		}
+	}
+
+	return &StatForecast{
+		Stat:     stat,
+		Method:   result.Method,
+		Level:    forecastLevel(opts),
+		History:  history,
+		Forecast: projection,
+	}, nil
+}
+
+func forecastLevel(opts forecast.Options) float64 {
+	if opts.Level <= 0 || opts.Level >= 1 {
+		return forecast.DefaultLevel
+	}
+	return opts.Level
+}
+
+func forecastPoint(p forecast.Point, min, max float64) ForecastPoint {
+	clamp := func(v float64) float64 {
+		return math.Round(math.Max(min, math.Min(max, v))*100) / 100
+	}
+	return ForecastPoint{Value: clamp(p.Value), Lower: clamp(p.Lower), Upper: clamp(p.Upper)}
+}
+
+// futureDates returns the dates of the points that fall within days after the
+// last of dates, spaced like the last two of them and written in the same
+// layout. Dates that cannot be parsed are labelled "+1d", "+2d" and so on.
+func futureDates(dates []string, days int, now time.Time) []string {
+	step := 1
+	var last time.Time
+	var layout string
+	if n := len(dates); n >= 2 {
+		var ok bool
+		last, layout, ok = parseTrendDate(dates[n-1], now)
+		if ok {
+			if previous, _, ok := parseTrendDate(dates[n-2], last); ok {
+				if gap := int(math.Round(last.Sub(previous).Hours() / 24)); gap > 1 {
+					step = gap
+				}
+			}
+		}
+	}
+
+	var future []string
+	for offset := step; offset <= days; offset += step {
+		if layout == "" {
+			future = append(future, fmt.Sprintf("+%dd", offset))
+			continue
+		}
+		future = append(future, last.AddDate(0, 0, offset).Format(layout))
+	}
+	if len(future) == 0 {
+		// A horizon shorter than the spacing still gets one point
+		if layout == "" {
+			return []string{fmt.Sprintf("+%dd", step)}
+		}
+		return []string{last.AddDate(0, 0, step).Format(layout)}
+	}
+	return future
+}
+
+// parseTrendDate parses the free-form date of a sentiment trend point and
+// returns the layout it was written in. Dates such as "Nov 5" carry no year
+// and are placed in the most recent year that does not put them after
+// notAfter.
+func parseTrendDate(text string, notAfter time.Time) (time.Time, string, bool) {
+	text = strings.TrimSpace(text)
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, layout, true
+		}
+	}
+	for _, layout := range []string{"Jan 2", "January 2", "2 Jan"} {
+		if t, err := time.Parse(layout, text); err == nil {
+			t = t.AddDate(notAfter.Year(), 0, 0)
+			if t.After(notAfter) {
+				t = t.AddDate(-1, 0, 0)
+			}
+			return t, layout, true
+		}
+	}
+	return time.Time{}, "", false
+}
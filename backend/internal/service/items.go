@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// itemStore reads and flags the items of one resource.
+type itemStore struct {
+	get   func(ctx context.Context, id string) (map[string]interface{}, error)
+	patch func(ctx context.Context, id string, fields bson.M, version int64) error
+}
+
+func storeOf[T any, P interface {
+	*T
+	ToResponse() map[string]interface{}
+}](store repository.ItemStore[T]) itemStore {
+	return itemStore{
+		get: func(ctx context.Context, id string) (map[string]interface{}, error) {
+			item, err := store.GetByID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return P(item).ToResponse(), nil
+		},
+		patch: store.Patch,
+	}
+}
+
+// Items fetches the items that relations and evidence can refer to, by
+// resource type.
+type Items struct {
+	stores map[models.ResourceType]itemStore
+}
+
+func NewItems(
+	clusters repository.ItemStore[models.ConversationCluster],
+	topics repository.ItemStore[models.DiscussionTopic],
+	risks repository.ItemStore[models.Risk],
+	opportunities repository.ItemStore[models.Opportunity],
+	actions repository.ItemStore[models.PriorityAction],
+) *Items {
+	return &Items{
+		stores: map[models.ResourceType]itemStore{
+			models.ResourceConversationClusters: storeOf(clusters),
+			models.ResourceDiscussionTopics:     storeOf(topics),
+			models.ResourceRisks:                storeOf(risks),
+			models.ResourceOpportunities:        storeOf(opportunities),
+			models.ResourcePriorityActions:      storeOf(actions),
+		},
+	}
+}
+
+// Get returns the item ref points at as its response map, or
+// mongo.ErrNoDocuments when it does not exist.
+func (i *Items) Get(ctx context.Context, ref models.ResourceRef) (map[string]interface{}, error) {
+	return i.stores[ref.Type].get(ctx, ref.ID.Hex())
+}
+
+// Flag sets the flagged_reason of the item ref points at, saying which item
+// it depended on was deleted, or clears it when reason is empty. An item that
+// no longer exists is left alone.
+func (i *Items) Flag(ctx context.Context, ref models.ResourceRef, reason string) error {
+	fields := bson.M{"flagged_reason": reason, "flagged_at": nil}
+	if reason != "" {
+		fields["flagged_at"] = time.Now()
+	}
+	// The flag changes without regard to the version the client read
+	err := i.stores[ref.Type].patch(ctx, ref.ID.Hex(), fields, repository.AnyVersion)
+	if err == mongo.ErrNoDocuments {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// ErrItemNotFound is returned when linking an item that does not exist.
+var ErrItemNotFound = errors.New("linked item not found")
+
+// ErrRelationExists is returned when the two items are already linked with
+// the same relation type.
+var ErrRelationExists = repository.ErrRelationExists
+
+type RelationService struct {
+	repo      repository.RelationStore
+	items     *Items
+	validator *validator.Validate
+}
+
+func NewRelationService(repo repository.RelationStore, items *Items) *RelationService {
+	return &RelationService{
+		repo:      repo,
+		items:     items,
+		validator: validator.New(),
+	}
+}
+
+func (s *RelationService) Validate(relation *models.Relation) error {
+	if err := s.validator.Struct(relation); err != nil {
+		return err
+	}
+	if relation.From == relation.To {
+		return errors.New("an item cannot be related to itself")
+	}
+	return nil
+}
+
+// Create links the two items of the relation after checking that both exist.
+// A flagged item that the relation gives a new item to depend on is no
+// longer flagged.
+func (s *RelationService) Create(ctx context.Context, relation *models.Relation) error {
+	ctx, span := tracer.Start(ctx, "RelationService.Create")
+	defer span.End()
+
+	if err := validated(ctx, s.Validate, relation); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	items := make(map[models.ResourceRef]map[string]interface{}, 2)
+	for _, ref := range []models.ResourceRef{relation.From, relation.To} {
+		item, err := s.items.Get(ctx, ref)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return fmt.Errorf("%w: %s %s", ErrItemNotFound, ref.Type, ref.ID.Hex())
+			}
+			return err
+		}
+		items[ref] = item
+	}
+
+	if err := s.repo.Create(ctx, relation); err != nil {
+		return err
+	}
+	if dependent, ok := relation.Dependent(); ok && items[dependent]["flagged_reason"] != "" {
+		return s.items.Flag(ctx, dependent, "")
+	}
+	return nil
+}
+
+func (s *RelationService) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Relation, int64, error) {
+	ctx, span := tracer.Start(ctx, "RelationService.GetAll")
+	defer span.End()
+
+	return s.repo.GetAll(ctx, filter, limit, offset)
+}
+
+func (s *RelationService) GetByID(ctx context.Context, id string) (*models.Relation, error) {
+	ctx, span := tracer.Start(ctx, "RelationService.GetByID")
+	defer span.End()
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *RelationService) Delete(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "RelationService.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("relation not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// RelatedItem is the other end of a relation, seen from one item
+type RelatedItem struct {
+	Relation  models.Relation
+	Direction string // "outgoing" when the item is the From end, "incoming" otherwise
+	Ref       models.ResourceRef
+	Item      map[string]interface{} // nil when the item was deleted
+}
+
+func (r *RelatedItem) ToResponse() map[string]interface{} {
+	return map[string]interface{}{
+		"relation_id": r.Relation.ID.Hex(),
+		"type":        r.Relation.Type,
+		"direction":   r.Direction,
+		"resource":    r.Ref.Type,
+		"id":          r.Ref.ID.Hex(),
+		"note":        r.Relation.Note,
+		"item":        r.Item,
+	}
+}
+
+// Related returns the items linked to ref, with each item expanded.
+func (s *RelationService) Related(ctx context.Context, ref models.ResourceRef) ([]RelatedItem, error) {
+	ctx, span := tracer.Start(ctx, "RelationService.Related")
+	defer span.End()
+
+	relations, err := s.repo.ForItem(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	related := make([]RelatedItem, 0, len(relations))
+	for _, relation := range relations {
+		item := RelatedItem{Relation: relation, Direction: "outgoing", Ref: relation.To}
+		if relation.To == ref {
+			item.Direction = "incoming"
+			item.Ref = relation.From
+		}
+		item.Item, err = s.items.Get(ctx, item.Ref)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		related = append(related, item)
+	}
+	return related, nil
+}
+
+// Cascade applies the deletion rules of each relation type to the item ref,
+// titled title, which is being deleted: the items that depended on it are
+// flagged with the reason, and every relation of the item is removed.
+func (s *RelationService) Cascade(ctx context.Context, ref models.ResourceRef, title string) error {
+	ctx, span := tracer.Start(ctx, "RelationService.Cascade")
+	defer span.End()
+
+	relations, err := s.repo.ForItem(ctx, ref)
+	if err != nil {
+		return err
+	}
+	reason := fmt.Sprintf("%s item %q was deleted", ref.Type, title)
+	for _, relation := range relations {
+		if dependent, ok := relation.Dependent(); ok && dependent != ref {
+			if err := s.items.Flag(ctx, dependent, reason); err != nil {
+				return err
+			}
+		}
+	}
+	return s.repo.DeleteMany(ctx, repository.ItemFilter(ref))
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/robfig/cron/v3"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+	"naradai-backend/internal/resource"
+)
+
+// ErrInvalidSchedule is returned for a cron expression or timezone that
+// cannot be parsed.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+type ReportScheduleService struct {
+	repo      repository.ReportScheduleStore
+	runs      repository.ReportRunStore
+	validator *validator.Validate
+}
+
+func NewReportScheduleService(repo repository.ReportScheduleStore, runs repository.ReportRunStore) *ReportScheduleService {
+	return &ReportScheduleService{
+		repo:      repo,
+		runs:      runs,
+		validator: validator.New(),
+	}
+}
+
+func (s *ReportScheduleService) Validate(schedule *models.ReportSchedule) error {
+	if err := s.validator.Struct(schedule); err != nil {
+		return err
+	}
+	_, err := nextRun(schedule, time.Now())
+	return err
+}
+
+// nextRun returns the first time after now that the schedule fires, in the
+// schedule's timezone. Standard five-field expressions and descriptors such
+// as "@weekly" are accepted.
+func nextRun(schedule *models.ReportSchedule, now time.Time) (time.Time, error) {
+	location := time.UTC
+	if schedule.Timezone != "" {
+		loc, err := time.LoadLocation(schedule.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSchedule, schedule.Timezone)
+		}
+		location = loc
+	}
+
+	parsed, err := cron.ParseStandard(schedule.Cron)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	return parsed.Next(now.In(location)).UTC(), nil
+}
+
+// scheduleNext sets NextRunAt for an active schedule and clears it otherwise.
+func scheduleNext(schedule *models.ReportSchedule) error {
+	schedule.NextRunAt = nil
+	if !schedule.Active() {
+		return nil
+	}
+	next, err := nextRun(schedule, time.Now())
+	if err != nil {
+		return err
+	}
+	schedule.NextRunAt = &next
+	return nil
+}
+
+func (s *ReportScheduleService) Create(ctx context.Context, schedule *models.ReportSchedule) error {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.Create")
+	defer span.End()
+
+	if err := validated(ctx, s.Validate, schedule); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	// A new schedule is active unless created otherwise, like the dashboard items
+	if schedule.IsActive == nil {
+		active := true
+		schedule.IsActive = &active
+	}
+	if err := scheduleNext(schedule); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return s.repo.Create(ctx, schedule)
+}
+
+func (s *ReportScheduleService) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.ReportSchedule, int64, error) {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.GetAll")
+	defer span.End()
+
+	return s.repo.GetAll(ctx, filter, limit, offset)
+}
+
+func (s *ReportScheduleService) GetByID(ctx context.Context, id string) (*models.ReportSchedule, error) {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.GetByID")
+	defer span.End()
+
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *ReportScheduleService) Update(ctx context.Context, id string, schedule *models.ReportSchedule, version int64) error {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.Update")
+	defer span.End()
+
+	if err := validated(ctx, s.Validate, schedule); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	existing, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("report schedule %w", resource.ErrNotFound)
+		}
+		return err
+	}
+
+	// Leaving out is_active keeps the schedule active or paused as it was
+	if schedule.IsActive == nil {
+		schedule.IsActive = existing.IsActive
+	}
+	if err := scheduleNext(schedule); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.repo.Update(ctx, id, schedule, version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("report schedule %w", resource.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *ReportScheduleService) Delete(ctx context.Context, id string, version int64) error {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.Delete")
+	defer span.End()
+
+	_, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("report schedule %w", resource.ErrNotFound)
+		}
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id, version); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("report schedule %w", resource.ErrNotFound)
+		}
+		return err
+	}
+	return nil
+}
+
+// Due returns the active schedules that should have fired by now.
+func (s *ReportScheduleService) Due(ctx context.Context, now time.Time) ([]models.ReportSchedule, error) {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.Due")
+	defer span.End()
+
+	return s.repo.Due(ctx, now)
+}
+
+// Claim advances a due schedule to its next run after now. It reports false
+// when the run was already claimed elsewhere and must not be executed.
+func (s *ReportScheduleService) Claim(ctx context.Context, schedule *models.ReportSchedule, now time.Time) (bool, error) {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.Claim")
+	defer span.End()
+
+	if schedule.NextRunAt == nil {
+		return false, nil
+	}
+
+	var next *time.Time
+	if t, err := nextRun(schedule, now); err == nil {
+		next = &t
+	}
+	// An expression that no longer parses leaves next nil, so the schedule
+	// fires this once and then stops until it is fixed
+	return s.repo.Claim(ctx, schedule.ID, *schedule.NextRunAt, next)
+}
+
+// StartRun records a new running execution of the schedule.
+func (s *ReportScheduleService) StartRun(ctx context.Context, schedule *models.ReportSchedule, trigger models.Trigger) (*models.ReportRun, error) {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.StartRun")
+	defer span.End()
+
+	run := &models.ReportRun{
+		ScheduleID: schedule.ID,
+		Trigger:    trigger,
+		Status:     models.ReportRunRunning,
+		Format:     schedule.Format,
+		Recipients: schedule.Recipients,
+	}
+	if err := s.runs.Create(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// FinishRun stores the outcome of the run on the run and its schedule.
+func (s *ReportScheduleService) FinishRun(ctx context.Context, run *models.ReportRun) error {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.FinishRun")
+	defer span.End()
+
+	if err := s.runs.Finish(ctx, run); err != nil {
+		return err
+	}
+	return s.repo.SetLastRun(ctx, run.ScheduleID, run.StartedAt, run.Status)
+}
+
+// Runs returns the run history of a schedule, newest first.
+func (s *ReportScheduleService) Runs(ctx context.Context, id string, limit, offset int64) ([]models.ReportRun, int64, error) {
+	ctx, span := tracer.Start(ctx, "ReportScheduleService.Runs")
+	defer span.End()
+
+	schedule, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.runs.GetBySchedule(ctx, schedule.ID, limit, offset)
+}
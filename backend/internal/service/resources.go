@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+	"naradai-backend/internal/resource"
+)
+
+// The services of the resources with no behaviour beyond the shared one.
+type (
+	PriorityActionService      = resource.Service[models.PriorityAction, *models.PriorityAction]
+	DiscussionTopicService     = resource.Service[models.DiscussionTopic, *models.DiscussionTopic]
+	CompetitiveAnalysisService = resource.Service[models.CompetitiveAnalysis, *models.CompetitiveAnalysis]
+	ConversationClusterService = resource.Service[models.ConversationCluster, *models.ConversationCluster]
+	RiskService                = resource.Service[models.Risk, *models.Risk]
+	OpportunityService         = resource.Service[models.Opportunity, *models.Opportunity]
+)
+
+func NewPriorityActionService(repo repository.OrderedStore[models.PriorityAction], relations *RelationService, evidence *EvidenceService) *PriorityActionService {
+	return resource.NewService(resource.PriorityActions, repo, linked(resource.PriorityActions, relations, evidence, func(action *models.PriorityAction) string {
+		return action.Title
+	}))
+}
+
+func NewDiscussionTopicService(repo repository.OrderedStore[models.DiscussionTopic], relations *RelationService, evidence *EvidenceService) *DiscussionTopicService {
+	return resource.NewService(resource.DiscussionTopics, repo, linked(resource.DiscussionTopics, relations, evidence, func(topic *models.DiscussionTopic) string {
+		return topic.Name
+	}))
+}
+
+func NewCompetitiveAnalysisService(repo repository.OrderedStore[models.CompetitiveAnalysis]) *CompetitiveAnalysisService {
+	return resource.NewService(resource.CompetitiveAnalyses, repo, resource.Hooks[models.CompetitiveAnalysis]{})
+}
+
+func NewConversationClusterService(repo repository.OrderedStore[models.ConversationCluster], relations *RelationService, evidence *EvidenceService) *ConversationClusterService {
+	return resource.NewService(resource.ConversationClusters, repo, linked(resource.ConversationClusters, relations, evidence, func(cluster *models.ConversationCluster) string {
+		return cluster.Theme
+	}))
+}
+
+func NewRiskService(repo repository.OrderedStore[models.Risk], relations *RelationService, evidence *EvidenceService) *RiskService {
+	return resource.NewService(resource.Risks, repo, linked(resource.Risks, relations, evidence, func(risk *models.Risk) string {
+		return risk.Title
+	}))
+}
+
+func NewOpportunityService(repo repository.OrderedStore[models.Opportunity], relations *RelationService, evidence *EvidenceService) *OpportunityService {
+	return resource.NewService(resource.Opportunities, repo, linked(resource.Opportunities, relations, evidence, func(opp *models.Opportunity) string {
+		return opp.Title
+	}))
+}
+
+// linked are the hooks of a resource whose items relations and evidence can
+// refer to: deleting an item cascades to both, and its related items are
+// listed from its relations. title names an item in the reason recorded on the
+// items a deletion flags.
+func linked[T any, P resource.Model[T]](r *resource.Resource[T, P], relations *RelationService, evidence *EvidenceService, title func(item *T) string) resource.Hooks[T] {
+	return resource.Hooks[T]{
+		Deleted: func(ctx context.Context, item *T) error {
+			ref := models.ResourceRef{Type: r.Type(), ID: P(item).ItemID()}
+			if err := relations.Cascade(ctx, ref, title(item)); err != nil {
+				return err
+			}
+			return evidence.DeleteForItem(ctx, ref)
+		},
+		Related: func(ctx context.Context, ref models.ResourceRef) ([]map[string]interface{}, error) {
+			related, err := relations.Related(ctx, ref)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]map[string]interface{}, len(related))
+			for i := range related {
+				items[i] = related[i].ToResponse()
+			}
+			return items, nil
+		},
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"naradai-backend/internal/models"
+	"naradai-backend/internal/repository"
+)
+
+// snapshotDateLayout is the format of snapshot dates.
+const snapshotDateLayout = "2006-01-02"
+
+// ErrSnapshotExists is returned when a snapshot for the date already exists.
+var ErrSnapshotExists = repository.ErrSnapshotExists
+
+type SnapshotService struct {
+	repo      repository.SnapshotStore
+	dashboard *DashboardService
+}
+
+func NewSnapshotService(repo repository.SnapshotStore, dashboard *DashboardService) *SnapshotService {
+	return &SnapshotService{
+		repo:      repo,
+		dashboard: dashboard,
+	}
+}
+
+// Take freezes the active items of every resource into a snapshot dated
+// date. An existing snapshot for the date is only overwritten with replace.
+func (s *SnapshotService) Take(ctx context.Context, date time.Time, label string, trigger models.Trigger, replace bool) (*models.Snapshot, error) {
+	ctx, span := tracer.Start(ctx, "SnapshotService.Take")
+	defer span.End()
+
+	dashboard, err := s.dashboard.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &models.Snapshot{
+		Date:    date.Format(snapshotDateLayout),
+		Label:   label,
+		Trigger: trigger,
+		Counts: map[string]int{
+			"dashboard_stats":       len(dashboard.Stats),
+			"priority_actions":      len(dashboard.PriorityActions),
+			"risks":                 len(dashboard.Risks),
+			"opportunities":         len(dashboard.Opportunities),
+			"sentiment_trends":      len(dashboard.SentimentTrends),
+			"discussion_topics":     len(dashboard.DiscussionTopics),
+			"competitive_analyses":  len(dashboard.CompetitiveAnalyses),
+			"conversation_clusters": len(dashboard.ConversationClusters),
+		},
+		Dashboard: *dashboard,
+	}
+	if snapshot.Label == "" {
+		snapshot.Label = "Snapshot " + snapshot.Date
+	}
+
+	if err := s.repo.Save(ctx, snapshot, replace); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// TakeScheduled is the snapshot job run by the scheduler. It never
+// overwrites a snapshot already taken that day.
+func (s *SnapshotService) TakeScheduled(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "SnapshotService.TakeScheduled")
+	defer span.End()
+
+	_, err := s.Take(ctx, time.Now().UTC(), "", models.TriggerSchedule, false)
+	if err == ErrSnapshotExists {
+		return nil
+	}
+	return err
+}
+
+func (s *SnapshotService) GetAll(ctx context.Context, filter bson.M, limit, offset int64) ([]models.Snapshot, int64, error) {
+	ctx, span := tracer.Start(ctx, "SnapshotService.GetAll")
+	defer span.End()
+
+	return s.repo.GetAll(ctx, filter, limit, offset)
+}
+
+// Get finds a snapshot by ID or by date (YYYY-MM-DD).
+func (s *SnapshotService) Get(ctx context.Context, ref string) (*models.Snapshot, error) {
+	ctx, span := tracer.Start(ctx, "SnapshotService.Get")
+	defer span.End()
+
+	var snapshot *models.Snapshot
+	var err error
+	if _, dateErr := time.Parse(snapshotDateLayout, ref); dateErr == nil {
+		snapshot, err = s.repo.GetByDate(ctx, ref)
+	} else if _, idErr := primitive.ObjectIDFromHex(ref); idErr == nil {
+		snapshot, err = s.repo.GetByID(ctx, ref)
+	} else {
+		err = mongo.ErrNoDocuments
+	}
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("snapshot not found")
+		}
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (s *SnapshotService) Delete(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "SnapshotService.Delete")
+	defer span.End()
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return fmt.Errorf("snapshot not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Diff compares two snapshots, each given by ID or date.
+func (s *SnapshotService) Diff(ctx context.Context, fromRef, toRef string) (*SnapshotDiff, error) {
+	ctx, span := tracer.Start(ctx, "SnapshotService.Diff")
+	defer span.End()
+
+	from, err := s.Get(ctx, fromRef)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.Get(ctx, toRef)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSnapshots(from, to), nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"math"
+
+	"naradai-backend/internal/models"
+)
+
+// SnapshotDiff describes what changed on the dashboard between two snapshots.
+type SnapshotDiff struct {
+	From         SnapshotRef      `json:"from"`
+	To           SnapshotRef      `json:"to"`
+	Risks        RiskDiff         `json:"risks"`
+	Sentiment    SentimentDiff    `json:"sentiment"`
+	ShareOfVoice ShareOfVoiceDiff `json:"share_of_voice"`
+}
+
+type SnapshotRef struct {
+	ID    string `json:"id"`
+	Date  string `json:"date"`
+	Label string `json:"label"`
+}
+
+// Movement is a numeric value in both snapshots.
+type Movement struct {
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+	Delta  float64 `json:"delta"`
+}
+
+type RiskSummary struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Severity    models.RiskSeverity `json:"severity"`
+	Probability int                 `json:"probability"`
+}
+
+type RiskChange struct {
+	ID             string              `json:"id"`
+	Title          string              `json:"title"`
+	SeverityBefore models.RiskSeverity `json:"severity_before"`
+	SeverityAfter  models.RiskSeverity `json:"severity_after"`
+	Probability    Movement            `json:"probability"`
+}
+
+// RiskDiff lists risks that appeared, risks that are no longer active and
+// risks whose severity or probability moved.
+type RiskDiff struct {
+	New      []RiskSummary `json:"new"`
+	Resolved []RiskSummary `json:"resolved"`
+	Changed  []RiskChange  `json:"changed"`
+}
+
+type SentimentChange struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Positive Movement `json:"positive_percent"`
+	Negative Movement `json:"negative_percent"`
+	Neutral  Movement `json:"neutral_percent"`
+}
+
+type SentimentDiff struct {
+	Changes []SentimentChange `json:"changes"`
+	Added   []string          `json:"added"`
+	Removed []string          `json:"removed"`
+}
+
+type CompetitorChange struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	ShareOfVoice Movement `json:"share_of_voice"`
+	Sentiment    Movement `json:"sentiment"`
+}
+
+type ShareOfVoiceDiff struct {
+	Changes []CompetitorChange `json:"changes"`
+	Added   []string           `json:"added"`
+	Removed []string           `json:"removed"`
+}
+
+// DiffSnapshots compares from (the earlier snapshot) with to. Items are
+// matched by ID, then by title or name, so an item that was deleted and
+// re-created still lines up.
+func DiffSnapshots(from, to *models.Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{
+		From: SnapshotRef{ID: from.ID.Hex(), Date: from.Date, Label: from.Label},
+		To:   SnapshotRef{ID: to.ID.Hex(), Date: to.Date, Label: to.Label},
+		Risks: RiskDiff{
+			New:      []RiskSummary{},
+			Resolved: []RiskSummary{},
+			Changed:  []RiskChange{},
+		},
+		Sentiment: SentimentDiff{
+			Changes: []SentimentChange{},
+			Added:   []string{},
+			Removed: []string{},
+		},
+		ShareOfVoice: ShareOfVoiceDiff{
+			Changes: []CompetitorChange{},
+			Added:   []string{},
+			Removed: []string{},
+		},
+	}
+
+	riskKey := func(r models.Risk) (string, string) { return r.ID.Hex(), r.Title }
+	matchItems(from.Dashboard.Risks, to.Dashboard.Risks, riskKey,
+		func(before, after *models.Risk) {
+			switch {
+			case before == nil:
+				diff.Risks.New = append(diff.Risks.New, riskSummary(after))
+			case after == nil:
+				diff.Risks.Resolved = append(diff.Risks.Resolved, riskSummary(before))
+			case before.Severity != after.Severity || before.Probability != after.Probability:
+				diff.Risks.Changed = append(diff.Risks.Changed, RiskChange{
+					ID:             after.ID.Hex(),
+					Title:          after.Title,
+					SeverityBefore: before.Severity,
+					SeverityAfter:  after.Severity,
+					Probability:    movement(float64(before.Probability), float64(after.Probability)),
+				})
+			}
+		})
+
+	trendKey := func(t models.SentimentTrend) (string, string) { return t.ID.Hex(), t.Title }
+	matchItems(from.Dashboard.SentimentTrends, to.Dashboard.SentimentTrends, trendKey,
+		func(before, after *models.SentimentTrend) {
+			switch {
+			case before == nil:
+				diff.Sentiment.Added = append(diff.Sentiment.Added, after.Title)
+			case after == nil:
+				diff.Sentiment.Removed = append(diff.Sentiment.Removed, before.Title)
+			default:
+				diff.Sentiment.Changes = append(diff.Sentiment.Changes, SentimentChange{
+					ID:       after.ID.Hex(),
+					Title:    after.Title,
+					Positive: movement(before.PositivePercent, after.PositivePercent),
+					Negative: movement(before.NegativePercent, after.NegativePercent),
+					Neutral:  movement(before.NeutralPercent, after.NeutralPercent),
+				})
+			}
+		})
+
+	competitorKey := func(c models.CompetitiveAnalysis) (string, string) { return c.ID.Hex(), c.Name }
+	matchItems(from.Dashboard.CompetitiveAnalyses, to.Dashboard.CompetitiveAnalyses, competitorKey,
+		func(before, after *models.CompetitiveAnalysis) {
+			switch {
+			case before == nil:
+				diff.ShareOfVoice.Added = append(diff.ShareOfVoice.Added, after.Name)
+			case after == nil:
+				diff.ShareOfVoice.Removed = append(diff.ShareOfVoice.Removed, before.Name)
+			default:
+				diff.ShareOfVoice.Changes = append(diff.ShareOfVoice.Changes, CompetitorChange{
+					ID:           after.ID.Hex(),
+					Name:         after.Name,
+					ShareOfVoice: movement(before.ShareOfVoice, after.ShareOfVoice),
+					Sentiment:    movement(before.Sentiment, after.Sentiment),
+				})
+			}
+		})
+
+	return diff
+}
+
+// matchItems pairs the items of two snapshots and calls fn once per pair, in
+// the order of after followed by the unmatched items of before. Either side
+// of a pair is nil when the item exists in only one snapshot.
+func matchItems[T any](before, after []T, key func(T) (id, name string), fn func(before, after *T)) {
+	byID := make(map[string]int, len(before))
+	byName := make(map[string]int, len(before))
+	for i, item := range before {
+		id, name := key(item)
+		byID[id] = i
+		if _, exists := byName[name]; !exists {
+			byName[name] = i
+		}
+	}
+
+	matched := make([]bool, len(before))
+	pairs := make([]int, len(after))
+	for i, item := range after {
+		pairs[i] = -1
+		id, _ := key(item)
+		if j, ok := byID[id]; ok {
+			pairs[i] = j
+			matched[j] = true
+		}
+	}
+	for i, item := range after {
+		if pairs[i] >= 0 {
+			continue
+		}
+		_, name := key(item)
+		if j, ok := byName[name]; ok && !matched[j] {
+			pairs[i] = j
+			matched[j] = true
+		}
+	}
+
+	for i := range after {
+		if pairs[i] >= 0 {
+			fn(&before[pairs[i]], &after[i])
+		} else {
+			fn(nil, &after[i])
+		}
+	}
+	for j := range before {
+		if !matched[j] {
+			fn(&before[j], nil)
+		}
+	}
+}
+
+func riskSummary(r *models.Risk) RiskSummary {
+	return RiskSummary{
+		ID:          r.ID.Hex(),
+		Title:       r.Title,
+		Severity:    r.Severity,
+		Probability: r.Probability,
+	}
+}
+
+func movement(before, after float64) Movement {
+	return Movement{
+		Before: before,
+		After:  after,
+		Delta:  math.Round((after-before)*100) / 100,
+	}
+}
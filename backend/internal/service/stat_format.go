@@ -0,0 +1,96 @@
+package service
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"naradai-backend/internal/models"
+)
+
+// currencySymbols are printed in place of the ISO code for common currencies.
+var currencySymbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"SGD": "S$",
+	"IDR": "Rp ",
+}
+
+// FormatStatValue renders a recorded stat value for display according to the
+// stat's unit: "12.4K" for numbers, "68.5%" for percentages and "Rp 2.5M" for
+// currency amounts.
+func FormatStatValue(value float64, unit models.StatUnit, currency string) string {
+	switch unit {
+	case models.StatUnitPercent:
+		return trimFloat(value, 1) + "%"
+	case models.StatUnitCurrency:
+		symbol, ok := currencySymbols[strings.ToUpper(currency)]
+		if !ok {
+			symbol = strings.ToUpper(currency) + " "
+		}
+		if value < 0 {
+			return "-" + symbol + abbreviate(-value)
+		}
+		return symbol + abbreviate(value)
+	default:
+		return abbreviate(value)
+	}
+}
+
+// FormatStatChange compares a stat's latest value with the previous one. The
+// change is relative ("+8.3%") except for percentage stats, which move in
+// percentage points ("+2.1pp"). An unchanged value is flat.
+func FormatStatChange(previous, current float64, unit models.StatUnit) (string, models.StatTrend) {
+	trend := models.StatTrendFlat
+	if current > previous {
+		trend = models.StatTrendUp
+	} else if current < previous {
+		trend = models.StatTrendDown
+	}
+
+	if unit == models.StatUnitPercent {
+		return signed(current-previous, 1) + "pp", trend
+	}
+	if previous == 0 {
+		if current == 0 {
+			return "0%", trend
+		}
+		return "n/a", trend
+	}
+	return signed((current-previous)/math.Abs(previous)*100, 1) + "%", trend
+}
+
+// abbreviate shortens large numbers with K, M and B suffixes. A value that
+// rounds up to the next suffix uses it ("1M" rather than "1000K").
+func abbreviate(value float64) string {
+	abs := math.Abs(value)
+	for _, unit := range []struct {
+		size   float64
+		suffix string
+	}{{1e9, "B"}, {1e6, "M"}, {1e3, "K"}} {
+		if math.Round(abs/unit.size*10)/10 >= 1 {
+			return trimFloat(value/unit.size, 1) + unit.suffix
+		}
+	}
+	return trimFloat(value, 2)
+}
+
+func signed(value float64, decimals int) string {
+	text := trimFloat(value, decimals)
+	if text != "0" && !strings.HasPrefix(text, "-") {
+		text = "+" + text
+	}
+	return text
+}
+
+// trimFloat formats value with at most decimals digits after the point.
+func trimFloat(value float64, decimals int) string {
+	scale := math.Pow(10, float64(decimals))
+	value = math.Round(value*scale) / scale
+	if value == 0 {
+		value = 0 // avoid "-0"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"naradai-backend/internal/models"
+)
+
+// parseWindow reads a binding window such as "7d" or "12h". An empty window
+// is unbounded and returns zero.
+func parseWindow(window string) (time.Duration, error) {
+	if window == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(window, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid window %q", window)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q", window)
+	}
+	return d, nil
+}
+
+// metricUnit is the unit a bound stat is displayed in when it has none.
+func metricUnit(metric models.StatMetric) models.StatUnit {
+	if metric == models.StatMetricActionCompletion {
+		return models.StatUnitPercent
+	}
+	return models.StatUnitNumber
+}
+
+// evaluate runs the metric query behind a binding.
+func (s *DashboardStatService) evaluate(ctx context.Context, binding *models.StatBinding) (float64, error) {
+	switch binding.Metric {
+	case models.StatMetricMentionCount, models.StatMetricAverageSentiment:
+		window, err := parseWindow(binding.Window)
+		if err != nil {
+			return 0, err
+		}
+		var since time.Time
+		if window > 0 {
+			since = time.Now().Add(-window)
+		}
+		count, sentiment, err := s.metrics.Mentions(ctx, since)
+		if binding.Metric == models.StatMetricMentionCount {
+			return count, err
+		}
+		return sentiment, err
+	case models.StatMetricRiskCount:
+		count, err := s.metrics.RiskCount(ctx, binding.Severity)
+		return float64(count), err
+	case models.StatMetricActionCompletion:
+		total, completed, err := s.metrics.ActionCounts(ctx)
+		if err != nil || total == 0 {
+			return 0, err
+		}
+		return float64(completed) / float64(total) * 100, nil
+	default:
+		return 0, fmt.Errorf("unknown metric %q", binding.Metric)
+	}
+}
+
+// resolveLive replaces the stored value of a stat with a live binding by the
+// current result of its metric. Change and Trend keep the values computed
+// from the series. When the query fails the stored value is kept.
+func (s *DashboardStatService) resolveLive(ctx context.Context, stat *models.DashboardStat) {
+	if stat.Binding == nil || !stat.Binding.Live {
+		return
+	}
+	value, err := s.evaluate(ctx, stat.Binding)
+	if err != nil {
+		slog.WarnContext(ctx, "dashboard stat: failed to evaluate metric", "stat_id", stat.ID.Hex(), "metric", stat.Binding.Metric, "error", err)
+		return
+	}
+	stat.Value = FormatStatValue(value, stat.Unit, stat.Currency)
+	stat.NumericValue = &value
+}
+
+// sample evaluates the binding of the stat and records the result as a point,
+// which recomputes the stat's Value, Change and Trend. Stats without a
+// binding are left alone.
+func (s *DashboardStatService) sample(ctx context.Context, stat *models.DashboardStat, at time.Time) error {
+	if stat.Binding == nil {
+		return nil
+	}
+	value, err := s.evaluate(ctx, stat.Binding)
+	if err != nil {
+		return err
+	}
+	point := models.StatPoint{StatID: stat.ID, At: at, Value: value}
+	if err := s.points.Record(ctx, []models.StatPoint{point}); err != nil {
+		return err
+	}
+	return s.refresh(ctx, stat.ID.Hex())
+}
+
+// RefreshBound samples every active stat with a binding. It runs on the
+// refresh schedule; a stat whose query fails keeps its previous values.
+func (s *DashboardStatService) RefreshBound(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "DashboardStatService.RefreshBound")
+	defer span.End()
+
+	var stats []models.DashboardStat
+	err := s.repo.Each(ctx, bson.M{"is_active": true, "binding": bson.M{"$ne": nil}}, func(stat *models.DashboardStat) error {
+		stats = append(stats, *stat)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	at := time.Now().Truncate(time.Minute)
+	for i := range stats {
+		if err := s.sample(ctx, &stats[i], at); err != nil {
+			slog.ErrorContext(ctx, "dashboard stat: failed to refresh metric", "stat_id", stats[i].ID.Hex(), "metric", stats[i].Binding.Metric, "error", err)
+		}
+	}
+	return nil
+}
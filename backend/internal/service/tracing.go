@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// tracer starts the spans of service methods. Each exported method taking a
+// context runs in a span named after it, such as RiskService.Create, between
+// the request span of the Gin middleware and the spans of its MongoDB
+// commands.
+var tracer = otel.Tracer("naradai-backend/internal/service")
+
+// validated runs validate in a span of its own, so a trace shows how long
+// validation took and whether it rejected the input.
+func validated[T any](ctx context.Context, validate func(T) error, value T) error {
+	_, span := tracer.Start(ctx, "validate")
+	defer span.End()
+	if err := validate(value); err != nil {
+		span.SetStatus(codes.Error, "validation failed")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package service
+
+import "naradai-backend/internal/repository"
+
+// AnyVersion skips the optimistic concurrency check on Update, Patch and Delete.
+const AnyVersion = repository.AnyVersion
+
+// ErrVersionConflict is returned when the expected version no longer matches
+// the stored document.
+var ErrVersionConflict = repository.ErrVersionConflict
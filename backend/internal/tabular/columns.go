@@ -0,0 +1,143 @@
+// Package tabular flattens models into rows and columns for spreadsheet
+// exports and imports.
+package tabular
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ListSeparator joins the elements of a list field inside a single cell.
+const ListSeparator = "; "
+
+// CellFormatter is implemented by nested model types (e.g. a risk indicator)
+// to control how each element is written into a cell.
+type CellFormatter interface {
+	FormatCell() string
+}
+
+// Column is a top-level field of a model, named after its JSON tag.
+type Column struct {
+	Name  string
+	index int
+}
+
+// Columns returns one column per JSON-tagged field of the struct type t, in
+// declaration order.
+func Columns(t reflect.Type) []Column {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	columns := make([]Column, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, Column{Name: name, index: i})
+	}
+	return columns
+}
+
+// Names returns the column names, used as the header row.
+func Names(columns []Column) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// Values returns the cell values of v for columns. Numbers and booleans are
+// kept as such so spreadsheets can compute on them; everything else,
+// including nested lists, is rendered as text.
+func Values(columns []Column, v interface{}) []interface{} {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		values[i] = cellValue(rv.Field(column.index))
+	}
+	return values
+}
+
+// FormatValue renders a cell value as text, for formats without types.
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+func cellValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Format(time.RFC3339)
+	case primitive.ObjectID:
+		if value.IsZero() {
+			return ""
+		}
+		return value.Hex()
+	case CellFormatter:
+		return value.FormatCell()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			parts[i] = FormatValue(cellValue(v.Index(i)))
+		}
+		return strings.Join(parts, ListSeparator)
+	default:
+		encoded, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
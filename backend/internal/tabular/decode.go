@@ -0,0 +1,226 @@
+package tabular
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CellParser is implemented by pointers to nested model types that can be
+// read back from the text written by their FormatCell method.
+type CellParser interface {
+	ParseCell(text string) error
+}
+
+// FieldError describes why a cell could not be used.
+type FieldError struct {
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// Decoder maps the columns of a file header onto the fields of a model.
+// Header names are matched against JSON names case-insensitively, with
+// spaces and dashes treated as underscores ("Share of Voice" matches
+// share_of_voice).
+type Decoder struct {
+	fields  []int // struct field index per header column, -1 when ignored
+	header  []string
+	ignored []string
+}
+
+// NewDecoder prepares a decoder for struct type t. Columns named in skip
+// (e.g. server-managed fields such as "id") are ignored.
+func NewDecoder(t reflect.Type, header []string, skip ...string) *Decoder {
+	byName := make(map[string]int)
+	for _, column := range Columns(t) {
+		byName[normalizeName(column.Name)] = column.index
+	}
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		skipped[normalizeName(name)] = true
+	}
+
+	d := &Decoder{fields: make([]int, len(header)), header: header}
+	for i, name := range header {
+		key := normalizeName(name)
+		index, ok := byName[key]
+		if !ok || skipped[key] {
+			d.fields[i] = -1
+			if !skipped[key] && key != "" {
+				d.ignored = append(d.ignored, name)
+			}
+			continue
+		}
+		d.fields[i] = index
+	}
+	return d
+}
+
+// Ignored returns the header columns that match no field of the model.
+func (d *Decoder) Ignored() []string {
+	return d.ignored
+}
+
+// Decode sets the fields of dst, a pointer to a struct, from row. Empty cells
+// leave the field at its zero value. Every unparseable cell is reported.
+func (d *Decoder) Decode(row []string, dst interface{}) []FieldError {
+	target := reflect.ValueOf(dst).Elem()
+	var errs []FieldError
+	for i, index := range d.fields {
+		if index < 0 || i >= len(row) {
+			continue
+		}
+		text := unescapeFormula(strings.TrimSpace(row[i]))
+		if text == "" {
+			continue
+		}
+		if err := setCell(target.Field(index), text); err != nil {
+			errs = append(errs, FieldError{Column: d.header[i], Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// ColumnName returns the JSON name of the struct field called fieldName, as
+// used in headers and error reports.
+func ColumnName(t reflect.Type, fieldName string) string {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, column := range Columns(t) {
+		if t.Field(column.index).Name == fieldName {
+			return column.Name
+		}
+	}
+	return fieldName
+}
+
+// Lookup returns the cell value of the column called name in v.
+func Lookup(v interface{}, name string) (interface{}, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	for _, column := range Columns(rv.Type()) {
+		if column.Name == name {
+			return cellValue(rv.Field(column.index)), true
+		}
+	}
+	return nil, false
+}
+
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+)
+
+func setCell(field reflect.Value, text string) error {
+	if parser, ok := field.Addr().Interface().(CellParser); ok {
+		return parser.ParseCell(text)
+	}
+
+	switch field.Type() {
+	case timeType:
+		for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, text); err == nil {
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid date %q", text)
+	case objectIDType:
+		id, err := primitive.ObjectIDFromHex(text)
+		if err != nil {
+			return fmt.Errorf("invalid id %q", text)
+		}
+		field.Set(reflect.ValueOf(id))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(text)
+	case reflect.Bool:
+		b, err := parseBool(text)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			// Spreadsheets often store whole numbers as "12.0"
+			f, ferr := strconv.ParseFloat(text, 64)
+			if ferr != nil || f != float64(int64(f)) {
+				return fmt.Errorf("invalid integer %q", text)
+			}
+			n = int64(f)
+		}
+		if field.OverflowInt(n) {
+			return fmt.Errorf("integer %q out of range", text)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, 64)
+		if err != nil || field.OverflowUint(n) {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimPrefix(text, "+"), 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", text)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		return setList(field, text)
+	default:
+		return json.Unmarshal([]byte(text), field.Addr().Interface())
+	}
+	return nil
+}
+
+// setList fills a slice from a cell written by Values: elements separated by
+// ListSeparator. A cell holding a JSON array is accepted as well.
+func setList(field reflect.Value, text string) error {
+	if strings.HasPrefix(text, "[") {
+		if err := json.Unmarshal([]byte(text), field.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid JSON list: %v", err)
+		}
+		return nil
+	}
+
+	parts := strings.Split(text, strings.TrimSpace(ListSeparator))
+	list := reflect.MakeSlice(field.Type(), 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		element := reflect.New(field.Type().Elem()).Elem()
+		if err := setCell(element, part); err != nil {
+			return err
+		}
+		list = reflect.Append(list, element)
+	}
+	field.Set(list)
+	return nil
+}
+
+func parseBool(text string) (bool, error) {
+	switch strings.ToLower(text) {
+	case "true", "yes", "y", "1":
+		return true, nil
+	case "false", "no", "n", "0":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %q", text)
+	}
+}
@@ -0,0 +1,48 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrEmptyTable is returned when a file has no header row.
+var ErrEmptyTable = errors.New("file has no header row")
+
+// ReadCSV reads a CSV file into its header and data rows.
+func ReadCSV(r io.Reader) (header []string, rows [][]string, err error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrEmptyTable
+	}
+	return records[0], records[1:], nil
+}
+
+// ReadXLSX reads a sheet of an XLSX file into its header and data rows. An
+// empty sheet name selects the first sheet.
+func ReadXLSX(r io.Reader, sheet string) (header []string, rows [][]string, err error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	if sheet == "" {
+		sheet = file.GetSheetName(0)
+	}
+	records, err := file.GetRows(sheet)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, ErrEmptyTable
+	}
+	return records[0], records[1:], nil
+}
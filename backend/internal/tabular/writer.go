@@ -0,0 +1,195 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is a file format supported by exports and imports.
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+	JSON Format = "json"
+)
+
+// ParseFormat validates a format name such as the ?format= query parameter.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case CSV, XLSX, JSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected csv, xlsx or json", name)
+	}
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case XLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// RowWriter writes a header and rows of cell values to one table.
+type RowWriter interface {
+	WriteHeader(names []string) error
+	WriteRow(values []interface{}) error
+}
+
+// CSVWriter writes rows as RFC 4180 CSV.
+type CSVWriter struct {
+	w *csv.Writer
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+func (w *CSVWriter) WriteHeader(names []string) error {
+	return w.w.Write(names)
+}
+
+func (w *CSVWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = FormatValue(escapeFormula(value))
+	}
+	return w.w.Write(record)
+}
+
+// Flush writes buffered rows to the underlying writer.
+func (w *CSVWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// Workbook builds an XLSX file one sheet at a time. Rows are streamed to
+// temporary storage by excelize, so large sheets are not held in memory.
+type Workbook struct {
+	file    *excelize.File
+	current *excelize.StreamWriter
+	sheets  int
+}
+
+func NewWorkbook() *Workbook {
+	return &Workbook{file: excelize.NewFile()}
+}
+
+// AddSheet starts a new sheet and returns its writer. The previous sheet is
+// finished and can no longer be written to.
+func (b *Workbook) AddSheet(name string) (RowWriter, error) {
+	if err := b.flush(); err != nil {
+		return nil, err
+	}
+
+	name = sheetName(name)
+	if b.sheets == 0 {
+		if err := b.file.SetSheetName(b.file.GetSheetName(0), name); err != nil {
+			return nil, err
+		}
+	} else if _, err := b.file.NewSheet(name); err != nil {
+		return nil, err
+	}
+	b.sheets++
+
+	stream, err := b.file.NewStreamWriter(name)
+	if err != nil {
+		return nil, err
+	}
+	b.current = stream
+	return &sheetWriter{stream: stream}, nil
+}
+
+// WriteTo finishes the workbook and writes the XLSX file to w.
+func (b *Workbook) WriteTo(w io.Writer) (int64, error) {
+	defer b.file.Close()
+	if err := b.flush(); err != nil {
+		return 0, err
+	}
+	b.file.SetActiveSheet(0)
+	return b.file.WriteTo(w)
+}
+
+func (b *Workbook) flush() error {
+	if b.current == nil {
+		return nil
+	}
+	err := b.current.Flush()
+	b.current = nil
+	return err
+}
+
+type sheetWriter struct {
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func (w *sheetWriter) WriteHeader(names []string) error {
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		values[i] = name
+	}
+	return w.WriteRow(values)
+}
+
+func (w *sheetWriter) WriteRow(values []interface{}) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	escaped := make([]interface{}, len(values))
+	for i, value := range values {
+		escaped[i] = escapeFormula(value)
+	}
+	return w.stream.SetRow(cell, escaped)
+}
+
+// formulaPrefixes are the first characters that make a spreadsheet evaluate
+// a cell as a formula.
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula prefixes text starting like a formula with an apostrophe, so
+// user content such as "=HYPERLINK(...)" is shown as text when an export is
+// opened in a spreadsheet instead of being evaluated. Decoder.Decode removes
+// the apostrophe again, so exports can still be imported. Numbers and other
+// values are returned unchanged.
+func escapeFormula(value interface{}) interface{} {
+	text, ok := value.(string)
+	if !ok || text == "" || !strings.ContainsRune(formulaPrefixes, rune(text[0])) {
+		return value
+	}
+	return "'" + text
+}
+
+// unescapeFormula undoes escapeFormula on an imported cell.
+func unescapeFormula(text string) string {
+	if len(text) > 1 && text[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(text[1])) {
+		return text[1:]
+	}
+	return text
+}
+
+// sheetName makes name acceptable to Excel: at most 31 characters and none
+// of : \ / ? * [ ].
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
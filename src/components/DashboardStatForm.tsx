@@ -122,7 +122,9 @@ export function DashboardStatForm({ open, onOpenChange, onSubmit, initialData, l
                 className={`flex items-center gap-1 text-xs px-2 py-0.5 rounded-full ${
                   watch("trend") === "up"
                     ? "bg-emerald-100 text-emerald-700"
-                    : "bg-red-100 text-red-700"
+                    : watch("trend") === "flat"
+                      ? "bg-slate-100 text-slate-700"
+                      : "bg-red-100 text-red-700"
                 }`}
               >
                 {watch("change") || "+0%"}
@@ -220,6 +222,7 @@ export function DashboardStatForm({ open, onOpenChange, onSubmit, initialData, l
                     <SelectContent>
                       <SelectItem value="up">Up (Green)</SelectItem>
                       <SelectItem value="down">Down (Red)</SelectItem>
+                      <SelectItem value="flat">Flat (Gray)</SelectItem>
                     </SelectContent>
                   </Select>
                 )}
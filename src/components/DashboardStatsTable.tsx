@@ -31,6 +31,7 @@ const getTrendBadge = (trend: string) => {
   const config = {
     up: { label: "Up", className: "bg-emerald-100 text-emerald-700 border-emerald-200" },
     down: { label: "Down", className: "bg-red-100 text-red-700 border-red-200" },
+    flat: { label: "Flat", className: "bg-slate-100 text-slate-700 border-slate-200" },
   };
   const cfg = config[trend as keyof typeof config] || config.up;
   return <Badge variant="outline" className={cfg.className}>{cfg.label}</Badge>;
@@ -95,7 +96,11 @@ export function DashboardStatsTable({ stats, onEdit, onDelete, loading }: Dashbo
                 <TableCell>
                   <span className={cn(
                     "text-sm font-medium px-2 py-0.5 rounded-full",
-                    stat.trend === "up" ? "bg-emerald-100 text-emerald-700" : "bg-red-100 text-red-700"
+                    stat.trend === "up"
+                      ? "bg-emerald-100 text-emerald-700"
+                      : stat.trend === "flat"
+                        ? "bg-slate-100 text-slate-700"
+                        : "bg-red-100 text-red-700"
                   )}>
                     {stat.change}
                   </span>
@@ -95,7 +95,9 @@ export function StatsOverview() {
                 className={`flex items-center gap-1 text-sm px-2.5 py-1 rounded-full ${
                   stat.trend === "up"
                     ? "bg-emerald-100 text-emerald-700"
-                    : "bg-red-100 text-red-700"
+                    : stat.trend === "flat"
+                      ? "bg-slate-100 text-slate-700"
+                      : "bg-red-100 text-red-700"
                 }`}
               >
                 {stat.change}
@@ -1,4 +1,4 @@
-export type StatTrend = "up" | "down";
+export type StatTrend = "up" | "down" | "flat";
 
 export interface DashboardStat {
   id: string;